@@ -0,0 +1,90 @@
+package dkg
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells a node the time, so that phase timeouts can be driven by something other than wall time
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After returns a channel which receives the current time once the given duration has elapsed
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is a Clock reading wall time
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// FakeClock is a Clock whose time only moves when it is advanced, for deterministic tests of timeouts.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeTimer
+}
+
+// fakeTimer is a channel waiting for a FakeClock to reach a deadline
+type fakeTimer struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+// NewFakeClock constructs a FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake clock's current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel which receives the fake clock's time once it has been advanced by at least d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeTimer{c.now.Add(d), ch})
+	return ch
+}
+
+// Advance moves the fake clock forward by d, firing every channel returned by After whose deadline
+// has been reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	waiting := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			waiting = append(waiting, w)
+			continue
+		}
+		w.c <- c.now
+	}
+	c.waiters = waiting
+}
+
+// SetClock replaces the clock the node uses to time out protocol phases, which defaults to wall time.
+func (n *Node) SetClock(clock Clock) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.clock = clock
+}
@@ -0,0 +1,47 @@
+package dkg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+
+	soon := clock.After(time.Second)
+	later := clock.After(time.Minute)
+	select {
+	case now := <-clock.After(0):
+		if !now.Equal(start) {
+			t.Errorf("Got unexpected time %v for zero duration", now)
+		}
+	default:
+		t.Errorf("Zero duration didn't fire immediately")
+	}
+
+	clock.Advance(time.Second)
+	if !clock.Now().Equal(start.Add(time.Second)) {
+		t.Errorf("Got unexpected time %v after advancing", clock.Now())
+	}
+	select {
+	case now := <-soon:
+		if !now.Equal(clock.Now()) {
+			t.Errorf("Got unexpected time %v from timer", now)
+		}
+	default:
+		t.Errorf("Timer didn't fire once its deadline was reached")
+	}
+	select {
+	case <-later:
+		t.Errorf("Timer fired before its deadline")
+	default:
+	}
+
+	clock.Advance(time.Hour)
+	select {
+	case <-later:
+	default:
+		t.Errorf("Timer didn't fire once its deadline was passed")
+	}
+}
@@ -0,0 +1,172 @@
+package dkg
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// Complaint represents an accusation by one node that another node (the dealer) sent it
+// secret shares which do not match the dealer's verification points.
+type Complaint struct {
+	// The ID of the node filing the complaint
+	AccuserID kyber.Scalar
+	// The ID of the dealer the complaint is filed against
+	AccusedID kyber.Scalar
+	// The secret shares the accuser received from the dealer, offered as evidence
+	SecretShare1 kyber.Scalar
+	SecretShare2 kyber.Scalar
+}
+
+// validate ensures a complaint names both an accuser and an accused node.
+func (c Complaint) validate() error {
+	if c.AccuserID == nil || c.AccusedID == nil {
+		return InvalidComplaintError{c, "missing accuser or accused ID"}
+	}
+	if c.AccuserID.Equal(c.AccusedID) {
+		return InvalidComplaintError{c, "node can't complain about itself"}
+	}
+	return nil
+}
+
+// complaintBy searches the complaints filed against a participant for one made by the given accuser.
+func (p *Participant) complaintBy(accuserID kyber.Scalar) *Complaint {
+	return findComplaintBy(p.complaints, accuserID)
+}
+
+// findComplaintBy searches a list of complaints for one made by the given accuser.
+func findComplaintBy(complaints []Complaint, accuserID kyber.Scalar) *Complaint {
+	for i := range complaints {
+		if complaints[i].AccuserID.Equal(accuserID) {
+			return &complaints[i]
+		}
+	}
+	return nil
+}
+
+// Complaints returns the complaints this node has filed against other nodes.
+func (n *Node) Complaints() []Complaint {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var complaints []Complaint
+	for _, p := range n.otherParticipants {
+		if c := p.complaintBy(n.id); c != nil {
+			complaints = append(complaints, *c)
+		}
+	}
+	return complaints
+}
+
+// ReceiveComplaint records a complaint filed by another node. Complaints against this node are kept
+// so they can be answered, while complaints against other nodes are tracked on the accused participant.
+func (n *Node) ReceiveComplaint(c Complaint) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if c.AccusedID.Equal(n.id) {
+		if findComplaintBy(n.complaintsAgainstSelf, c.AccuserID) != nil {
+			return DuplicateComplaintError{c}
+		}
+		n.complaintsAgainstSelf = append(n.complaintsAgainstSelf, c)
+		return nil
+	}
+
+	p, err := n.getParticipantByID(c.AccusedID)
+	if p == nil || err != nil {
+		return err
+	}
+	if p.complaintBy(c.AccuserID) != nil {
+		return DuplicateComplaintError{c}
+	}
+	p.complaints = append(p.complaints, c)
+	return nil
+}
+
+// Justification is a dealer's public answer to a complaint, revealing the secret shares it sent to
+// the accuser so that every node can check them against the dealer's verification points.
+type Justification struct {
+	// The ID of the dealer answering the complaint
+	DealerID kyber.Scalar
+	// The ID of the node which filed the complaint
+	AccuserID kyber.Scalar
+	// The secret shares the dealer sent to the accuser
+	SecretShare1 kyber.Scalar
+	SecretShare2 kyber.Scalar
+}
+
+// justificationFor searches the valid justifications a participant has published for one answering
+// the given accuser.
+func (p *Participant) justificationFor(accuserID kyber.Scalar) *Justification {
+	for i := range p.justifications {
+		if p.justifications[i].AccuserID.Equal(accuserID) {
+			return &p.justifications[i]
+		}
+	}
+	return nil
+}
+
+// Justify produces a justification answering a complaint filed against this node.
+func (n *Node) Justify(c Complaint) (*Justification, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if !c.AccusedID.Equal(n.id) {
+		return nil, InvalidComplaintError{c, "complaint is not against this node"}
+	}
+
+	share1, share2 := n.EvaluatePolynomials(c.AccuserID)
+	return &Justification{n.id, c.AccuserID, share1, share2}, nil
+}
+
+// Justifications produces justifications answering every complaint other nodes have filed against this node.
+func (n *Node) Justifications() []Justification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	justifications := make([]Justification, 0, len(n.complaintsAgainstSelf))
+	for _, c := range n.complaintsAgainstSelf {
+		share1, share2 := n.EvaluatePolynomials(c.AccuserID)
+		justifications = append(justifications, Justification{n.id, c.AccuserID, share1, share2})
+	}
+	return justifications
+}
+
+// ProcessJustification verifies a justification published by another node against that node's
+// verification points and records the outcome on the dealer's participant entry. If the complaint
+// being answered was filed by this node and the justification holds, the revealed shares replace
+// the ones this node originally received.
+func (n *Node) ProcessJustification(j Justification) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if j.DealerID == nil || j.AccuserID == nil || j.SecretShare1 == nil || j.SecretShare2 == nil {
+		return false, InvalidJustificationError{j}
+	}
+
+	p, err := n.getParticipantByID(j.DealerID)
+	if p == nil || err != nil {
+		return false, err
+	}
+
+	if p.complaintBy(j.AccuserID) == nil {
+		return false, ComplaintNotFoundError{j.AccuserID, j.DealerID}
+	}
+	if p.justificationFor(j.AccuserID) != nil {
+		return true, nil
+	}
+
+	if !n.verifySecretShares(j.AccuserID, j.SecretShare1, j.SecretShare2, p.verificationPoints) {
+		p.invalidJustification = true
+		return false, nil
+	}
+
+	p.justifications = append(p.justifications, j)
+	if j.AccuserID.Equal(n.id) {
+		p.secretShare1 = j.SecretShare1
+		p.secretShare2 = j.SecretShare2
+	}
+	return true, nil
+}
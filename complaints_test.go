@@ -0,0 +1,193 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+func TestComplaints(t *testing.T) {
+	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+
+	node, err := NewNode(
+		curve, g2, zkParam, timeout,
+		id, secretPoly1, secretPoly2,
+	)
+	if node == nil || err != nil {
+		t.Fatalf("Could not create new node: %v", err)
+	}
+
+	dealerID := curve.Scalar().SetInt64(2)
+	otherID := curve.Scalar().SetInt64(3)
+	invalidShare1, invalidShare2 := curve.Scalar().SetInt64(9), curve.Scalar().SetInt64(9)
+	addParticipantToNodeList(
+		node, dealerID, invalidShare1, invalidShare2, PointTuple{curve.Point().Base()},
+	)
+
+	t.Run("Invalid shares file a complaint", func(t *testing.T) {
+		verified, err := node.ProcessSecretShareVerification(dealerID)
+		if verified || err != nil {
+			t.Fatalf("Verified a participant with invalid shares: %v", err)
+		}
+
+		complaints := node.Complaints()
+		if len(complaints) != 1 {
+			t.Fatalf("Expected one complaint but got %v", complaints)
+		}
+		c := complaints[0]
+		if !c.AccuserID.Equal(id) || !c.AccusedID.Equal(dealerID) ||
+			!c.SecretShare1.Equal(invalidShare1) || !c.SecretShare2.Equal(invalidShare2) {
+			t.Errorf("Got unexpected complaint %v", c)
+		}
+
+		// verifying again must not file a second complaint
+		node.ProcessSecretShareVerification(dealerID)
+		if len(node.Complaints()) != 1 {
+			t.Errorf("Filed duplicate complaints: %v", node.Complaints())
+		}
+	})
+
+	t.Run("Receive complaint against participant", func(t *testing.T) {
+		c := Complaint{otherID, dealerID, invalidShare1, invalidShare2}
+		if err := node.ReceiveComplaint(c); err != nil {
+			t.Fatalf("Could not receive complaint %v: %v", c, err)
+		}
+
+		p, _ := node.getParticipantByID(dealerID)
+		if len(p.complaints) != 2 || p.complaintBy(otherID) == nil {
+			t.Errorf("Complaint was not tracked on participant: %v", p.complaints)
+		}
+
+		err := node.ReceiveComplaint(c)
+		if reflect.TypeOf(err) != reflect.TypeOf(DuplicateComplaintError{}) {
+			t.Errorf("Got unexpected error for duplicate complaint: %v", err)
+		}
+	})
+
+	t.Run("Receive complaint against self", func(t *testing.T) {
+		c := Complaint{otherID, id, invalidShare1, invalidShare2}
+		if err := node.ReceiveComplaint(c); err != nil {
+			t.Fatalf("Could not receive complaint %v: %v", c, err)
+		}
+		if len(node.complaintsAgainstSelf) != 1 {
+			t.Errorf("Complaint against self was not tracked: %v", node.complaintsAgainstSelf)
+		}
+	})
+
+	t.Run("Invalid complaints", func(t *testing.T) {
+		badComplaints := []Complaint{
+			{nil, dealerID, invalidShare1, invalidShare2},
+			{otherID, nil, invalidShare1, invalidShare2},
+			{dealerID, dealerID, invalidShare1, invalidShare2},
+		}
+		for _, bad := range badComplaints {
+			err := node.ReceiveComplaint(bad)
+			if reflect.TypeOf(err) != reflect.TypeOf(InvalidComplaintError{}) {
+				t.Errorf("Got unexpected error for invalid complaint %v: %v", bad, err)
+			}
+		}
+
+		unknown := Complaint{otherID, curve.Scalar().SetInt64(99999), invalidShare1, invalidShare2}
+		if err := node.ReceiveComplaint(unknown); err == nil {
+			t.Errorf("Received complaint against unknown participant %v", unknown)
+		}
+	})
+}
+
+func TestJustifications(t *testing.T) {
+	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+
+	dealer, err := NewNode(
+		curve, g2, zkParam, timeout,
+		id, secretPoly1, secretPoly2,
+	)
+	if dealer == nil || err != nil {
+		t.Fatalf("Could not create dealer node: %v", err)
+	}
+
+	accuserID := curve.Scalar().SetInt64(2)
+	accuser, err := GenerateNode(
+		curve, g2, zkParam, timeout,
+		accuserID, bn256.NewSuite().RandomStream(), len(secretPoly1),
+	)
+	if accuser == nil || err != nil {
+		t.Fatalf("Could not create accuser node: %v", err)
+	}
+
+	// the dealer delivers tampered shares to the accuser
+	share1, share2 := dealer.EvaluatePolynomials(accuserID)
+	tampered := curve.Scalar().Add(share1, curve.Scalar().One())
+	addParticipantToNodeList(accuser, id, tampered, share2, dealer.VerificationPoints())
+
+	verified, err := accuser.ProcessSecretShareVerification(id)
+	if verified || err != nil {
+		t.Fatalf("Verified tampered shares: %v", err)
+	}
+	complaint := accuser.Complaints()[0]
+
+	t.Run("Only the accused can justify", func(t *testing.T) {
+		j, err := accuser.Justify(complaint)
+		if j != nil || err == nil {
+			t.Errorf("Accuser justified a complaint against another node: %v", j)
+		}
+	})
+
+	t.Run("Valid justification settles complaint", func(t *testing.T) {
+		if err := dealer.ReceiveComplaint(complaint); err != nil {
+			t.Fatalf("Dealer could not receive complaint: %v", err)
+		}
+		justifications := dealer.Justifications()
+		if len(justifications) != 1 {
+			t.Fatalf("Expected one justification but got %v", justifications)
+		}
+		j, err := dealer.Justify(complaint)
+		if j == nil || err != nil {
+			t.Fatalf("Dealer could not justify complaint: %v", err)
+		}
+		if !j.SecretShare1.Equal(justifications[0].SecretShare1) {
+			t.Errorf("Justifications don't match: %v != %v", *j, justifications[0])
+		}
+
+		ok, err := accuser.ProcessJustification(*j)
+		if !ok || err != nil {
+			t.Fatalf("Could not verify valid justification %v: %v", *j, err)
+		}
+
+		p, _ := accuser.getParticipantByID(id)
+		if !p.secretShare1.Equal(share1) || !p.secretShare2.Equal(share2) {
+			t.Errorf("Revealed shares were not adopted by the accuser")
+		}
+		if p.justificationFor(accuserID) == nil || p.invalidJustification {
+			t.Errorf("Justification outcome was not recorded on participant")
+		}
+	})
+
+	t.Run("Invalid justification is recorded", func(t *testing.T) {
+		otherID := curve.Scalar().SetInt64(3)
+		observer, _ := GenerateNode(
+			curve, g2, zkParam, timeout,
+			otherID, bn256.NewSuite().RandomStream(), len(secretPoly1),
+		)
+		addParticipantToNodeList(observer, id, nil, nil, dealer.VerificationPoints())
+		observer.ReceiveComplaint(complaint)
+
+		forged := Justification{id, accuserID, tampered, share2}
+		ok, err := observer.ProcessJustification(forged)
+		if ok || err != nil {
+			t.Errorf("Verified forged justification %v: %v", forged, err)
+		}
+		p, _ := observer.getParticipantByID(id)
+		if !p.invalidJustification {
+			t.Errorf("Invalid justification was not recorded on participant")
+		}
+	})
+
+	t.Run("Justification without complaint", func(t *testing.T) {
+		j := Justification{id, curve.Scalar().SetInt64(3), share1, share2}
+		_, err := accuser.ProcessJustification(j)
+		if reflect.TypeOf(err) != reflect.TypeOf(ComplaintNotFoundError{}) {
+			t.Errorf("Got unexpected error for justification without complaint: %v", err)
+		}
+	})
+}
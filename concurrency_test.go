@@ -0,0 +1,89 @@
+package dkg
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+func TestConcurrentDelivery(t *testing.T) {
+	count, threshold := 16, 4
+	curve, g2, zkParam, timeout, _, _, _ := getValidNodeParamsForTesting(t)
+
+	nodes := make([]*Node, count)
+	for i := range nodes {
+		n, err := GenerateNode(
+			curve, g2, zkParam, timeout,
+			curve.Scalar().SetInt64(int64(i+1)), bn256.NewSuite().RandomStream(), threshold,
+		)
+		if n == nil || err != nil {
+			t.Fatalf("Could not generate node: %v", err)
+		}
+		nodes[i] = n
+	}
+	receiver, accuser := nodes[0], nodes[1]
+	useFakeClockForTesting(receiver)
+	if err := receiver.Start(count); err != nil {
+		t.Fatalf("Could not start protocol: %v", err)
+	}
+
+	// every dealer's messages are handled on their own goroutine, as a network handler would
+	var wg sync.WaitGroup
+	for _, dealer := range nodes[1:] {
+		wg.Add(1)
+		go func(dealer *Node) {
+			defer wg.Done()
+
+			share1, share2 := dealer.EvaluatePolynomials(receiver.id)
+			if err := receiver.ReceiveSecretShares(dealer.id, share1, share2); err != nil {
+				t.Errorf("Could not receive secret shares from %v: %v", dealer.id, err)
+			}
+			if err := receiver.AddVerificationPoints(dealer.id, dealer.VerificationPoints()); err != nil {
+				t.Errorf("Could not add verification points of %v: %v", dealer.id, err)
+			}
+			if ok, err := receiver.ProcessPublicKeyPart(dealer.id, nil, dealer.PublicKeyPart(), dealer.PublicKeyPartProof(nil)); !ok || err != nil {
+				t.Errorf("Could not process public key part of %v: %v", dealer.id, err)
+			}
+			if ok, err := receiver.ProcessSecretShareVerification(dealer.id); !ok || err != nil {
+				t.Errorf("Could not verify secret shares of %v: %v", dealer.id, err)
+			}
+			if dealer != accuser {
+				receiver.ReceiveComplaint(Complaint{accuser.id, dealer.id, nil, nil})
+			}
+			receiver.UpdatePhase()
+			receiver.Complaints()
+		}(dealer)
+	}
+	wg.Wait()
+
+	if phase := receiver.Phase(); phase != ComplaintsPhase {
+		t.Errorf("Expected complaints phase once every share was delivered, got %v", phase)
+	}
+	if len(receiver.otherParticipants) != count-1 {
+		t.Fatalf("Got %v participants, expected %v", len(receiver.otherParticipants), count-1)
+	}
+	for _, p := range receiver.otherParticipants {
+		if !p.id.Equal(accuser.id) && len(p.complaints) != 1 {
+			t.Errorf("Participant %v has %v complaints, expected 1", p.id, len(p.complaints))
+		}
+	}
+
+	// complaints are answered concurrently as well
+	for _, dealer := range nodes[2:] {
+		wg.Add(1)
+		go func(dealer *Node) {
+			defer wg.Done()
+
+			justification, _ := dealer.Justify(Complaint{accuser.id, dealer.id, nil, nil})
+			if ok, err := receiver.ProcessJustification(*justification); !ok || err != nil {
+				t.Errorf("Could not process justification of %v: %v", dealer.id, err)
+			}
+		}(dealer)
+	}
+	wg.Wait()
+
+	if qual := receiver.ComputeQUAL(); len(qual) != count {
+		t.Errorf("Got unexpected QUAL %v", qual)
+	}
+}
@@ -0,0 +1,208 @@
+package dkg
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"time"
+
+	"github.com/dedis/kyber"
+)
+
+// Config holds the parameters a node is constructed with
+type Config struct {
+	// The vector space underlying the protocol. Typically will be an elliptic curve.
+	Curve kyber.Group
+	// A second element of the vector space for which the scalar k in the relation k * G = G2 is unknown
+	G2 kyber.Point
+	// A domain-separation string G2 is derived from with DeriveG2. Optional; when set, G2 is derived from it
+	// if missing and must match the derivation otherwise.
+	G2Domain string
+	// A zero knowledge parameter agreed upon within the DKG group
+	ZKParam kyber.Scalar
+	// A timeout for each phase of the protocol
+	Timeout time.Duration
+	// The clock phase timeouts are measured with. Defaults to wall time.
+	Clock Clock
+	// The source of randomness the secret polynomials are picked with. Defaults to crypto/rand.
+	Rand cipher.Stream
+
+	// The ID of the node. Must be a non-zero scalar of the curve.
+	ID kyber.Scalar
+	// The number of coefficients of the secret polynomials, which is the number of secret key shares
+	// needed to reconstruct the group secret key
+	Threshold int
+	// The number of nodes in the group, including this one
+	ParticipantCount int
+	// The IDs of every node in the group, including this one. Optional; when set, participants with
+	// other IDs are rejected.
+	ParticipantIDs []kyber.Scalar
+}
+
+// Option sets a parameter of a Config
+type Option func(*Config)
+
+// WithCurve sets the vector space underlying the protocol.
+func WithCurve(curve kyber.Group) Option {
+	return func(c *Config) { c.Curve = curve }
+}
+
+// WithG2 sets the second element of the vector space used in verification points.
+func WithG2(g2 kyber.Point) Option {
+	return func(c *Config) { c.G2 = g2 }
+}
+
+// WithG2Domain sets the domain-separation string the second element of the vector space is derived from.
+func WithG2Domain(domain string) Option {
+	return func(c *Config) { c.G2Domain = domain }
+}
+
+// WithZKParam sets the zero knowledge parameter agreed upon within the group.
+func WithZKParam(zkParam kyber.Scalar) Option {
+	return func(c *Config) { c.ZKParam = zkParam }
+}
+
+// WithTimeout sets the timeout for each phase of the protocol.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) { c.Timeout = timeout }
+}
+
+// WithClock sets the clock phase timeouts are measured with.
+func WithClock(clock Clock) Option {
+	return func(c *Config) { c.Clock = clock }
+}
+
+// WithRand sets the source of randomness the secret polynomials are picked with.
+func WithRand(rand cipher.Stream) Option {
+	return func(c *Config) { c.Rand = rand }
+}
+
+// WithID sets the ID of the node.
+func WithID(id kyber.Scalar) Option {
+	return func(c *Config) { c.ID = id }
+}
+
+// WithThreshold sets the number of secret key shares needed to reconstruct the group secret key.
+func WithThreshold(threshold int) Option {
+	return func(c *Config) { c.Threshold = threshold }
+}
+
+// WithParticipantCount sets the number of nodes in the group, including this one.
+func WithParticipantCount(count int) Option {
+	return func(c *Config) { c.ParticipantCount = count }
+}
+
+// WithParticipantIDs sets the IDs of every node in the group, including this one, and the number of
+// nodes in the group along with them.
+func WithParticipantIDs(ids ...kyber.Scalar) Option {
+	return func(c *Config) {
+		c.ParticipantIDs = ids
+		c.ParticipantCount = len(ids)
+	}
+}
+
+// randomStream is a cipher.Stream of random bytes read from crypto/rand
+type randomStream struct{}
+
+func (randomStream) XORKeyStream(dst, src []byte) {
+	buf := make([]byte, len(src))
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	for i := range src {
+		dst[i] = src[i] ^ buf[i]
+	}
+}
+
+// Validate checks that a Config describes a node which can take part in the protocol.
+func (c Config) Validate() error {
+	if c.Curve == nil {
+		return InvalidConfigError{"curve", "is missing"}
+	}
+	if c.G2 == nil || c.G2.Equal(c.Curve.Point().Null()) {
+		return InvalidCurvePointError{c.Curve, c.G2}
+	}
+	if c.G2Domain != "" {
+		if err := VerifyG2(c.Curve, c.G2, c.G2Domain); err != nil {
+			return err
+		}
+	}
+	if c.ZKParam == nil {
+		return InvalidConfigError{"zkParam", "is missing"}
+	}
+	if c.Timeout <= 0 {
+		return InvalidConfigError{"timeout", "must be positive"}
+	}
+	if c.ID == nil || c.ID.Equal(c.Curve.Scalar().Zero()) {
+		return InvalidCurveScalarError{c.Curve, c.ID}
+	}
+	if c.Threshold < 1 {
+		return InvalidConfigError{"threshold", "must be at least 1"}
+	}
+	if c.ParticipantCount < c.Threshold || c.ParticipantCount < 2 {
+		return InvalidParticipantCountError{c.ParticipantCount, c.Threshold}
+	}
+
+	if c.ParticipantIDs == nil {
+		return nil
+	}
+	if len(c.ParticipantIDs) != c.ParticipantCount {
+		return InvalidParticipantCountError{len(c.ParticipantIDs), c.Threshold}
+	}
+	seen := make(map[string]bool, len(c.ParticipantIDs))
+	for _, id := range c.ParticipantIDs {
+		if id == nil || id.Equal(c.Curve.Scalar().Zero()) {
+			return InvalidCurveScalarError{c.Curve, id}
+		}
+		key := scalarKey(id)
+		if seen[key] {
+			return DuplicateParticipantIDError{id}
+		}
+		seen[key] = true
+	}
+	if !seen[scalarKey(c.ID)] {
+		return InvalidConfigError{"participantIDs", "don't include the node's own ID"}
+	}
+	return nil
+}
+
+// New constructs a node with freshly generated secret polynomials from the given options.
+func New(opts ...Option) (*Node, error) {
+	var c Config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return NewFromConfig(c)
+}
+
+// NewFromConfig validates a Config and constructs a node with freshly generated secret polynomials from it.
+func NewFromConfig(c Config) (*Node, error) {
+	if c.G2 == nil && c.G2Domain != "" && c.Curve != nil {
+		g2, err := DeriveG2(c.Curve, c.G2Domain)
+		if err != nil {
+			return nil, err
+		}
+		c.G2 = g2
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.Rand == nil {
+		c.Rand = randomStream{}
+	}
+
+	n, err := GenerateNode(c.Curve, c.G2, c.ZKParam, c.Timeout, c.ID, c.Rand, c.Threshold)
+	if n == nil || err != nil {
+		return nil, err
+	}
+	if c.Clock != nil {
+		n.clock = c.Clock
+	}
+	n.groupSize = c.ParticipantCount
+	if c.ParticipantIDs != nil {
+		n.participantIDs = make(map[string]bool, len(c.ParticipantIDs))
+		for _, id := range c.ParticipantIDs {
+			n.participantIDs[scalarKey(id)] = true
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,85 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+// getValidConfigForTesting returns options describing the first node of a group of four
+func getValidConfigForTesting(t *testing.T) []Option {
+	curve, g2, zkParam, timeout, _, _, _ := getValidNodeParamsForTesting(t)
+	return []Option{
+		WithCurve(curve), WithG2(g2), WithZKParam(zkParam), WithTimeout(timeout),
+		WithRand(bn256.NewSuite().RandomStream()),
+		WithID(curve.Scalar().SetInt64(1)), WithThreshold(3), WithParticipantCount(4),
+	}
+}
+
+func TestNewFromOptions(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	n, err := New(append(getValidConfigForTesting(t), WithClock(clock))...)
+	if n == nil || err != nil {
+		t.Fatalf("Could not create node from options: %v", err)
+	}
+	if len(n.secretPoly1) != 3 || len(n.secretPoly2) != 3 || n.clock != Clock(clock) {
+		t.Errorf("Node doesn't match its config")
+	}
+	if err := n.Start(5); reflect.TypeOf(err) != reflect.TypeOf(InvalidParticipantCountError{}) {
+		t.Errorf("Got unexpected error starting with a different participant count: %v", err)
+	}
+	if err := n.Start(4); err != nil {
+		t.Errorf("Could not start with the configured participant count: %v", err)
+	}
+
+	t.Run("Invalid options", func(t *testing.T) {
+		one, two := curve.Scalar().SetInt64(1), curve.Scalar().SetInt64(2)
+		invalid := []struct {
+			name string
+			opt  Option
+			err  error
+		}{
+			{"Missing curve", WithCurve(nil), InvalidConfigError{}},
+			{"Null g2", WithG2(curve.Point().Null()), InvalidCurvePointError{}},
+			{"Missing zkParam", WithZKParam(nil), InvalidConfigError{}},
+			{"Zero timeout", WithTimeout(0), InvalidConfigError{}},
+			{"Zero ID", WithID(curve.Scalar().Zero()), InvalidCurveScalarError{}},
+			{"Zero threshold", WithThreshold(0), InvalidConfigError{}},
+			{"Threshold above participant count", WithThreshold(5), InvalidParticipantCountError{}},
+			{"Duplicate IDs", WithParticipantIDs(one, two, two), DuplicateParticipantIDError{}},
+			{"Zero participant ID", WithParticipantIDs(one, two, curve.Scalar().Zero()), InvalidCurveScalarError{}},
+			{"Own ID missing", WithParticipantIDs(two, curve.Scalar().SetInt64(3), curve.Scalar().SetInt64(4)), InvalidConfigError{}},
+		}
+		for _, bad := range invalid {
+			n, err := New(append(getValidConfigForTesting(t), bad.opt)...)
+			if n != nil || reflect.TypeOf(err) != reflect.TypeOf(bad.err) {
+				t.Errorf("%v: got unexpected error: %v", bad.name, err)
+			}
+		}
+	})
+
+	t.Run("Participant IDs", func(t *testing.T) {
+		ids := make([]kyber.Scalar, 4)
+		for i := range ids {
+			ids[i] = curve.Scalar().SetInt64(int64(i + 1))
+		}
+		n, err := New(append(getValidConfigForTesting(t), WithParticipantIDs(ids...))...)
+		if n == nil || err != nil {
+			t.Fatalf("Could not create node with participant IDs: %v", err)
+		}
+
+		dealer, _ := New(append(getValidConfigForTesting(t), WithID(ids[1]))...)
+		if err := n.AddVerificationPoints(ids[1], dealer.VerificationPoints()); err != nil {
+			t.Errorf("Could not add a configured participant: %v", err)
+		}
+		stranger := curve.Scalar().SetInt64(5)
+		if err := n.AddVerificationPoints(stranger, dealer.VerificationPoints()); reflect.TypeOf(err) != reflect.TypeOf(InvalidParticipantIDError{}) {
+			t.Errorf("Got unexpected error adding a participant which isn't configured: %v", err)
+		}
+	})
+}
@@ -4,6 +4,7 @@ package dkg
 import (
 	"crypto/cipher"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/dedis/kyber"
@@ -30,8 +31,12 @@ func (p ScalarPolynomial) validate(curve kyber.Group) []error {
 	return errors
 }
 
-// node represents a dkg node
-type node struct {
+// Node represents a dkg node. It is safe for concurrent use.
+type Node struct {
+	// Guards the node's mutable state. Exported methods acquire it, while unexported methods expect
+	// it to be held. The configuration and secret polynomials never change after construction.
+	mu sync.Mutex
+
 	// The vector space underlying the protocol. Typically will be an elliptic curve.
 	curve kyber.Group
 	// A second element of the vector space for which the scalar k in the relation k * G = G2 is unknown.
@@ -41,6 +46,8 @@ type node struct {
 	zkParam kyber.Scalar
 	// A timeout for communications in the protocol
 	timeout time.Duration
+	// The clock phase timeouts are measured with
+	clock Clock
 
 	// The ID associated with a node. Must be a scalar from the finite field underlying the vector space
 	id kyber.Scalar
@@ -48,12 +55,38 @@ type node struct {
 	secretPoly1 ScalarPolynomial
 	// The second secret polynomial for the node
 	secretPoly2 ScalarPolynomial
-
-	// This node's view of other nodes in the protocol
-	otherParticipants []Participant
+	// The number of nodes in the group, if configured up front
+	groupSize int
+	// The canonical encodings of the IDs of every node in the group, if configured up front
+	participantIDs map[string]bool
+
+	// This node's view of other nodes in the protocol, in the order they were added
+	otherParticipants []*Participant
+	// The same participants keyed by the canonical encoding of their IDs
+	participants map[string]*Participant
+	// Complaints other nodes have filed against this node
+	complaintsAgainstSelf []Complaint
+	// Whether the qualified set of dealers has been computed
+	qualComputed bool
+
+	// The long-term private key this node signs its messages with
+	identityKey kyber.Scalar
+	// The long-term public keys of every node, keyed by the canonical encoding of their IDs
+	identityKeys map[string]kyber.Point
+	// The messages carrying the encrypted secret shares broadcast by every dealer, keyed by the dealer's and
+	// recipient's IDs
+	encryptedShares map[string]*EncryptedSharesMessage
+
+	// The number of nodes taking part in the protocol, including this one. Zero until the protocol is started.
+	participantCount int
+	// The phase of the protocol the node is in
+	phase Phase
+	// When the current phase started
+	phaseStarted time.Time
 }
 
-// NewNode constructs a new node for DKG given some configuration variables.
+// NewNode constructs a new node for DKG given some configuration variables. If a domain-separation string
+// is given, g2 must be the one derived from it with DeriveG2.
 func NewNode(
 	curve kyber.Group,
 	g2 kyber.Point,
@@ -63,11 +96,20 @@ func NewNode(
 	id kyber.Scalar,
 	secretPoly1 ScalarPolynomial,
 	secretPoly2 ScalarPolynomial,
-) (*node, error) {
+	g2Domain ...string,
+) (*Node, error) {
 
 	if g2.Equal(curve.Point().Null()) {
 		return nil, InvalidCurvePointError{curve, g2}
 	}
+	for _, domain := range g2Domain {
+		if err := VerifyG2(curve, g2, domain); err != nil {
+			return nil, err
+		}
+	}
+	if zkParam == nil {
+		return nil, InvalidCurveScalarError{curve, zkParam}
+	}
 
 	var polyErrors []error
 	polyErrors = secretPoly1.validate(curve)
@@ -84,19 +126,18 @@ func NewNode(
 		return nil, InvalidCurveScalarPolynomialError{curve, secretPoly2, polyErrors}
 	}
 
-	return &node{
-		curve, g2, zkParam, timeout,
-		id, secretPoly1, secretPoly2,
-		nil,
+	return &Node{
+		curve: curve, g2: g2, zkParam: zkParam, timeout: timeout, clock: realClock{},
+		id: id, secretPoly1: secretPoly1, secretPoly2: secretPoly2,
 	}, nil
 }
 
-func (n *node) ScalarBaseMult(s kyber.Scalar) kyber.Point {
+func (n *Node) ScalarBaseMult(s kyber.Scalar) kyber.Point {
 	return n.curve.Point().Mul(s, n.curve.Point().Base())
 }
 
 // PublicKeyPart retrieves the vector related to the constant term of a node's first secret polynomial.
-func (n *node) PublicKeyPart() (p kyber.Point) {
+func (n *Node) PublicKeyPart() (p kyber.Point) {
 	return n.ScalarBaseMult(n.secretPoly1[0])
 }
 
@@ -105,7 +146,7 @@ type PointTuple []kyber.Point
 
 // VerificationPoints retrives a set of vectors which may be used to verify that secret shares
 // sent to a node are legitimate.
-func (n *node) VerificationPoints() PointTuple {
+func (n *Node) VerificationPoints() PointTuple {
 	// [c1 * G + c2 * G2 for c1, c2 in zip(spoly1, spoly2)]
 	vpts := make(PointTuple, len(n.secretPoly1))
 	for i, c1 := range n.secretPoly1 {
@@ -128,24 +169,137 @@ type Participant struct {
 	// The other node's public verification points, which are vectors derived
 	// from the first and second secret polynomials.
 	verificationPoints PointTuple
+	// Complaints filed against the other node, at most one per accuser
+	complaints []Complaint
+	// Justifications the other node has published which correctly answer a complaint
+	justifications []Justification
+	// Whether the other node has published a justification which failed verification
+	invalidJustification bool
+	// Whether the other node has been excluded from the qualified set
+	disqualified bool
+	// The other node's Feldman commitments to its first secret polynomial
+	publicCoefficients PointTuple
+	// Whether the other node's public coefficients or the proof of knowledge of its public key part failed
+	// verification, in which case its contribution has to be reconstructed. Never cleared once set.
+	invalidPublicCoefficients bool
+	// Whether the other node's public coefficients or public key part were still missing when key extraction
+	// timed out, in which case its contribution is reconstructed unless they arrive late.
+	missingPublicCoefficients bool
+	// Secret shares of the other node disclosed by other members of the qualified set
+	reconstructionShares []ReconstructionShare
+	// The constant term of the other node's first secret polynomial, if it had to be reconstructed
+	reconstructedSecret kyber.Scalar
+	// Whether a verifiable complaint against the other node has been upheld
+	upheldComplaint bool
+	// The other node's public key part, once its proof of knowledge has been verified
+	publicKeyPart kyber.Point
 }
 
 // Searches a node for its view of another node, given the other node's ID.
-func (n *node) getParticipantByID(id kyber.Scalar) (p *Participant, _ error) {
-	for _, participant := range n.otherParticipants {
-		if participant.id == id {
-			matchingParticipant := Participant{
-				participant.id,
-				participant.secretShare1,
-				participant.secretShare2,
-				participant.verificationPoints,
-			}
-			return &matchingParticipant, nil
+func (n *Node) getParticipantByID(id kyber.Scalar) (p *Participant, _ error) {
+	if id != nil {
+		if p, ok := n.participants[scalarKey(id)]; ok {
+			return p, nil
 		}
 	}
 	return nil, ParticipantNotFoundError{n.id, id}
 }
 
+// participant looks up a node's view of another node, adding an empty entry for it if there is none yet.
+func (n *Node) participant(id kyber.Scalar) *Participant {
+	if p, _ := n.getParticipantByID(id); p != nil {
+		return p
+	}
+	if n.participants == nil {
+		n.participants = make(map[string]*Participant)
+	}
+	p := &Participant{id: id}
+	n.otherParticipants = append(n.otherParticipants, p)
+	n.participants[scalarKey(id)] = p
+	return p
+}
+
+// Participants returns copies of this node's view of every other node, in the order they were added, so
+// that their public data may be inspected with MarshalParticipantJSON. The copies don't change as the
+// protocol progresses.
+func (n *Node) Participants() []*Participant {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	participants := make([]*Participant, len(n.otherParticipants))
+	for i, p := range n.otherParticipants {
+		view := *p
+		view.complaints = append([]Complaint(nil), p.complaints...)
+		view.justifications = append([]Justification(nil), p.justifications...)
+		view.reconstructionShares = append([]ReconstructionShare(nil), p.reconstructionShares...)
+		participants[i] = &view
+	}
+	return participants
+}
+
+// ID returns the ID of the node a participant represents.
+func (p *Participant) ID() kyber.Scalar {
+	return p.id
+}
+
+// validateParticipantID ensures another node may be added as a participant
+func (n *Node) validateParticipantID(id kyber.Scalar) error {
+	if id == nil || id.Equal(n.id) {
+		return InvalidParticipantIDError{n.id, id}
+	}
+	if n.participantIDs != nil && !n.participantIDs[scalarKey(id)] {
+		return InvalidParticipantIDError{n.id, id}
+	}
+	return nil
+}
+
+// AddVerificationPoints adds another node as a participant along with the verification points it broadcast.
+// There must be one point per coefficient of the secret polynomials, and a participant's points may only
+// be added once.
+func (n *Node) AddVerificationPoints(id kyber.Scalar, verificationPoints PointTuple) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.validateParticipantID(id); err != nil {
+		return err
+	}
+	if len(verificationPoints) != len(n.secretPoly1) {
+		return InvalidPointsLengthError{len(verificationPoints)}
+	}
+	if err := verificationPoints.validate(n.curve); err != nil {
+		return err
+	}
+
+	p := n.participant(id)
+	if p.verificationPoints != nil {
+		return DuplicateVerificationPointsError{n.id, id}
+	}
+	p.verificationPoints = verificationPoints
+	return nil
+}
+
+// ReceiveSecretShares records the secret shares another node sent to this node, adding it as a participant
+// if needed. A participant's shares may only be recorded once; they are checked against its verification
+// points with ProcessSecretShareVerification.
+func (n *Node) ReceiveSecretShares(id kyber.Scalar, secretShare1, secretShare2 kyber.Scalar) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.validateParticipantID(id); err != nil {
+		return err
+	}
+	if secretShare1 == nil || secretShare2 == nil {
+		return MissingSecretShareError{n.id, id}
+	}
+
+	p := n.participant(id)
+	if p.secretShare1 != nil || p.secretShare2 != nil {
+		return DuplicateSecretSharesError{n.id, id}
+	}
+	p.secretShare1, p.secretShare2 = secretShare1, secretShare2
+	return nil
+}
+
 // Compares two PointTuples, returning true if all vectors of each tuple are equal
 func comparePointTuples(a, b PointTuple) bool {
 	for i, pointA := range a {
@@ -159,34 +313,25 @@ func comparePointTuples(a, b PointTuple) bool {
 	return true
 }
 
-// Verifies that the secret shares a node has received from another node matches the other node's
-// verification points
-func (n *node) ProcessSecretShareVerification(id kyber.Scalar) (bool, error) {
-	// alice's address
-	ownAddress := n.id
-
-	// bob's node
-	p, err := n.getParticipantByID(id)
-	if p == nil || err != nil {
-		return false, err
+// verifySecretShares checks that a pair of secret shares evaluated at x is consistent with
+// a dealer's verification points.
+func (n *Node) verifySecretShares(x, share1, share2 kyber.Scalar, verificationPoints PointTuple) bool {
+	if share1 == nil || share2 == nil || len(verificationPoints) == 0 {
+		return false
 	}
 
-	// bob's shares
-	share1 := p.secretShare1
-	share2 := p.secretShare2
-
 	// verify left hand side
 	a := n.ScalarBaseMult(share1)
 	b := n.curve.Point().Mul(share2, n.g2)
 	vpoint := n.curve.Point().Add(a, b)
 	vlhs := PointTuple{vpoint}
 
-	// bob's verification points
-	vrhs := make(PointTuple, len(n.secretPoly1))
+	// dealer's verification points
+	vrhs := make(PointTuple, 1)
 
 	// verify right hand side
 	pow := n.curve.Scalar().One()
-	for i, point := range p.verificationPoints {
+	for i, point := range verificationPoints {
 		p := n.curve.Point().Mul(pow, point)
 		if i == 0 {
 			vrhs[0] = p
@@ -194,14 +339,42 @@ func (n *node) ProcessSecretShareVerification(id kyber.Scalar) (bool, error) {
 			vrhs[0] = n.curve.Point().Add(vrhs[0], p)
 		}
 
-		pow.Mul(pow, ownAddress)
+		pow.Mul(pow, x)
 	}
 
-	if comparePointTuples(vlhs, vrhs) {
+	return comparePointTuples(vlhs, vrhs)
+}
+
+// Verifies that the secret shares a node has received from another node matches the other node's
+// verification points. If they don't, the node files a complaint against the other node, which
+// may then be retrieved with Complaints and broadcast to the rest of the group.
+func (n *Node) ProcessSecretShareVerification(id kyber.Scalar) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.processSecretShareVerification(id)
+}
+
+func (n *Node) processSecretShareVerification(id kyber.Scalar) (bool, error) {
+	// bob's node
+	p, err := n.getParticipantByID(id)
+	if p == nil || err != nil {
+		return false, err
+	}
+
+	if n.verifySecretShares(n.id, p.secretShare1, p.secretShare2, p.verificationPoints) {
 		return true, nil
 	}
-	// else fire complaint message
-	// participant.get_or_create_complaint_by_complainer_address(ownAddress)
+
+	complaint := Complaint{
+		AccuserID:    n.id,
+		AccusedID:    p.id,
+		SecretShare1: p.secretShare1,
+		SecretShare2: p.secretShare2,
+	}
+	if p.complaintBy(n.id) == nil {
+		p.complaints = append(p.complaints, complaint)
+	}
 	return false, nil
 }
 
@@ -221,7 +394,7 @@ func (poly ScalarPolynomial) evaluate(x kyber.Scalar) kyber.Scalar {
 
 // EvaluatePolynomials evaluates a node's secret polynomials given another node's ID, returning
 // the node's secret shares for the other node.
-func (n *node) EvaluatePolynomials(id kyber.Scalar) (kyber.Scalar, kyber.Scalar) {
+func (n *Node) EvaluatePolynomials(id kyber.Scalar) (kyber.Scalar, kyber.Scalar) {
 	return n.secretPoly1.evaluate(id), n.secretPoly2.evaluate(id)
 }
 
@@ -251,7 +424,7 @@ func GenerateNode(
 	id kyber.Scalar,
 	rand cipher.Stream,
 	threshold int,
-) (*node, error) {
+) (*Node, error) {
 	secretPoly1, err := generateSecretPolynomial(curve, rand, threshold)
 	if secretPoly1 == nil || err != nil {
 		return nil, err
@@ -21,8 +21,10 @@ func getValidNodeParamsForTesting(t *testing.T) (
 	secretPoly2 ScalarPolynomial,
 ) {
 	curve = bn256.NewSuite().G1()
-	g2 = curve.Point().Base()
-	g2.Mul(curve.Scalar().SetInt64(42), g2)
+	g2, err := DeriveG2(curve, "dkg test")
+	if err != nil {
+		t.Fatalf("Could not derive g2: %v", err)
+	}
 
 	zkParam = curve.Scalar().SetBytes([]byte("arbitrary zk proof parameter"))
 	timeout = time.Duration(100 * time.Millisecond)
@@ -49,19 +51,22 @@ func serializePoint(curve kyber.Group, pt kyber.Point) string {
 }
 
 func addParticipantToNodeList(
-	n *node,
+	n *Node,
 	id kyber.Scalar,
 	secretShare1 kyber.Scalar,
 	secretShare2 kyber.Scalar,
 	verificationPoints PointTuple,
-) *node {
+) *Node {
 	participant := Participant{
-		id,
-		secretShare1,
-		secretShare2,
-		verificationPoints,
+		id:                 id,
+		secretShare1:       secretShare1,
+		secretShare2:       secretShare2,
+		verificationPoints: verificationPoints,
 	}
-	n.otherParticipants = append(n.otherParticipants, participant)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	*n.participant(id) = participant
 	return n
 }
 
@@ -104,6 +109,16 @@ func TestInvalidNodeConstruction(t *testing.T) {
 		}
 	})
 
+	t.Run("Missing zkParam", func(t *testing.T) {
+		node, err := NewNode(
+			curve, g2, nil, timeout,
+			id, secretPoly1, secretPoly2,
+		)
+		if node != nil || reflect.TypeOf(err) != reflect.TypeOf(InvalidCurveScalarError{}) {
+			t.Errorf("Got unexpected result from construction without zkParam: %v, %v", node, err)
+		}
+	})
+
 	t.Run("Invalid polynomials", func(t *testing.T) {
 		badPolys := []struct {
 			poly1, poly2 ScalarPolynomial
@@ -163,7 +178,10 @@ func TestInvalidNodeConstruction(t *testing.T) {
 }
 
 func TestValidNode(t *testing.T) {
-	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+	curve, _, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+
+	// the expected verification points were computed with this g2
+	g2 := curve.Point().Mul(curve.Scalar().SetInt64(42), nil)
 
 	node, err := NewNode(
 		curve, g2, zkParam, timeout,
@@ -292,6 +310,86 @@ func TestProcessSecretShareVerification(t *testing.T) {
 	}
 }
 
+func TestAddParticipant(t *testing.T) {
+	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+
+	dealer, err := NewNode(curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2)
+	if dealer == nil || err != nil {
+		t.Fatalf("Could not create dealer: %v", err)
+	}
+	receiverID := curve.Scalar().SetInt64(2)
+	receiver, err := NewNode(curve, g2, zkParam, timeout, receiverID, secretPoly2, secretPoly1)
+	if receiver == nil || err != nil {
+		t.Fatalf("Could not create receiver: %v", err)
+	}
+
+	share1, share2 := dealer.EvaluatePolynomials(receiverID)
+	points := dealer.VerificationPoints()
+
+	t.Run("Invalid participant", func(t *testing.T) {
+		for _, badID := range []kyber.Scalar{nil, receiverID} {
+			if err := receiver.AddVerificationPoints(badID, points); reflect.TypeOf(err) != reflect.TypeOf(InvalidParticipantIDError{}) {
+				t.Errorf("Got unexpected error adding verification points for ID %v: %v", badID, err)
+			}
+			if err := receiver.ReceiveSecretShares(badID, share1, share2); reflect.TypeOf(err) != reflect.TypeOf(InvalidParticipantIDError{}) {
+				t.Errorf("Got unexpected error receiving secret shares from ID %v: %v", badID, err)
+			}
+		}
+	})
+
+	t.Run("Invalid values", func(t *testing.T) {
+		for _, bad := range []PointTuple{nil, points[:2], append(points, points[0])} {
+			if err := receiver.AddVerificationPoints(id, bad); reflect.TypeOf(err) != reflect.TypeOf(InvalidPointsLengthError{}) {
+				t.Errorf("Got unexpected error adding %v verification points: %v", len(bad), err)
+			}
+		}
+		missing := append(PointTuple{}, points...)
+		missing[1] = nil
+		if err := receiver.AddVerificationPoints(id, missing); reflect.TypeOf(err) != reflect.TypeOf(InvalidCurvePointError{}) {
+			t.Errorf("Got unexpected error adding missing verification point: %v", err)
+		}
+		if err := receiver.ReceiveSecretShares(id, share1, nil); reflect.TypeOf(err) != reflect.TypeOf(MissingSecretShareError{}) {
+			t.Errorf("Got unexpected error receiving missing secret share: %v", err)
+		}
+	})
+
+	if err := receiver.ReceiveSecretShares(id, share1, share2); err != nil {
+		t.Fatalf("Could not receive secret shares: %v", err)
+	}
+	if err := receiver.AddVerificationPoints(id, points); err != nil {
+		t.Fatalf("Could not add verification points: %v", err)
+	}
+	if verified, err := receiver.ProcessSecretShareVerification(id); !verified || err != nil {
+		t.Errorf("Could not verify received secret shares: %v", err)
+	}
+	if len(receiver.otherParticipants) != 1 {
+		t.Errorf("Got %v participants, expected 1", len(receiver.otherParticipants))
+	}
+
+	t.Run("ID decoded from the wire", func(t *testing.T) {
+		encoded, _ := id.MarshalBinary()
+		decodedID := curve.Scalar()
+		if err := decodedID.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("Could not decode ID: %v", err)
+		}
+		if p, err := receiver.getParticipantByID(decodedID); p == nil || err != nil || !p.id.Equal(id) {
+			t.Errorf("Could not find participant by decoded ID %v: %v", decodedID, err)
+		}
+		if verified, err := receiver.ProcessSecretShareVerification(decodedID); !verified || err != nil {
+			t.Errorf("Could not verify secret shares by decoded ID: %v", err)
+		}
+	})
+
+	t.Run("Duplicates", func(t *testing.T) {
+		if err := receiver.AddVerificationPoints(id, points); reflect.TypeOf(err) != reflect.TypeOf(DuplicateVerificationPointsError{}) {
+			t.Errorf("Got unexpected error adding verification points twice: %v", err)
+		}
+		if err := receiver.ReceiveSecretShares(id, share2, share1); reflect.TypeOf(err) != reflect.TypeOf(DuplicateSecretSharesError{}) {
+			t.Errorf("Got unexpected error receiving secret shares twice: %v", err)
+		}
+	})
+}
+
 func TestEvaluatePolynomials(t *testing.T) {
 	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
 
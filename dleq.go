@@ -0,0 +1,54 @@
+package dkg
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// DLEQProof is a non-interactive Chaum-Pedersen proof that two points have the same discrete logarithm
+// relative to two bases, i.e. that xG = x * G and xH = x * H for some secret x.
+type DLEQProof struct {
+	Challenge kyber.Scalar
+	Response  kyber.Scalar
+}
+
+// dleqChallenge computes the Fiat-Shamir challenge of a DLEQ proof, bound to the group's zero knowledge parameter
+func dleqChallenge(group kyber.Group, zkParam kyber.Scalar, G, H, xG, xH, vG, vH kyber.Point) kyber.Scalar {
+	return hashToScalar(group,
+		[]byte("dkg dleq challenge"),
+		marshalAll(zkParam),
+		marshalAll(G, H, xG, xH, vG, vH),
+	)
+}
+
+// proveDLEQ proves that x * G and x * H share the discrete logarithm x, returning both points and the proof.
+// The commitment nonce is derived deterministically from the secret and every input of the challenge, since
+// the same nonce under two different challenges would reveal x.
+func proveDLEQ(group kyber.Group, zkParam, x kyber.Scalar, G, H kyber.Point) (kyber.Point, kyber.Point, DLEQProof) {
+	xG := group.Point().Mul(x, G)
+	xH := group.Point().Mul(x, H)
+
+	v := hashToScalar(group, []byte("dkg dleq nonce"), marshalAll(x), marshalAll(zkParam), marshalAll(G, H, xG, xH))
+	vG := group.Point().Mul(v, G)
+	vH := group.Point().Mul(v, H)
+
+	c := dleqChallenge(group, zkParam, G, H, xG, xH, vG, vH)
+	// r = v - c * x
+	r := group.Scalar().Sub(v, group.Scalar().Mul(c, x))
+	return xG, xH, DLEQProof{c, r}
+}
+
+// verifyDLEQ checks a proof produced by proveDLEQ that xG and xH share a discrete logarithm relative to G and H.
+func verifyDLEQ(group kyber.Group, zkParam kyber.Scalar, G, H, xG, xH kyber.Point, proof DLEQProof) error {
+	if proof.Challenge == nil || proof.Response == nil || xG == nil || xH == nil {
+		return InvalidProofError{}
+	}
+
+	// v * G = r * G + c * xG and v * H = r * H + c * xH
+	vG := group.Point().Add(group.Point().Mul(proof.Response, G), group.Point().Mul(proof.Challenge, xG))
+	vH := group.Point().Add(group.Point().Mul(proof.Response, H), group.Point().Mul(proof.Challenge, xH))
+
+	if !dleqChallenge(group, zkParam, G, H, xG, xH, vG, vH).Equal(proof.Challenge) {
+		return InvalidProofError{}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+func TestDLEQProof(t *testing.T) {
+	curve, _, zkParam, _, _, _, _ := getValidNodeParamsForTesting(t)
+	rand := bn256.NewSuite().RandomStream()
+
+	x := curve.Scalar().Pick(rand)
+	G := curve.Point().Base()
+	H := curve.Point().Pick(rand)
+
+	xG, xH, proof := proveDLEQ(curve, zkParam, x, G, H)
+	if !xG.Equal(curve.Point().Mul(x, G)) || !xH.Equal(curve.Point().Mul(x, H)) {
+		t.Fatalf("Proof returned wrong points %v, %v", xG, xH)
+	}
+	if err := verifyDLEQ(curve, zkParam, G, H, xG, xH, proof); err != nil {
+		t.Fatalf("Could not verify valid proof: %v", err)
+	}
+
+	other := curve.Point().Pick(rand)
+	badProofs := []struct {
+		name           string
+		zkParam        kyber.Scalar
+		xG, xH         kyber.Point
+		challenge, res kyber.Scalar
+	}{
+		{"Wrong zkParam", curve.Scalar().Add(zkParam, curve.Scalar().One()), xG, xH, proof.Challenge, proof.Response},
+		{"Wrong xG", zkParam, other, xH, proof.Challenge, proof.Response},
+		{"Wrong xH", zkParam, xG, other, proof.Challenge, proof.Response},
+		{"Wrong challenge", zkParam, xG, xH, proof.Response, proof.Response},
+		{"Wrong response", zkParam, xG, xH, proof.Challenge, proof.Challenge},
+		{"Missing response", zkParam, xG, xH, proof.Challenge, nil},
+	}
+	t.Run("Fresh commitment per zkParam", func(t *testing.T) {
+		otherZKParam := curve.Scalar().Add(zkParam, curve.Scalar().One())
+		_, _, otherProof := proveDLEQ(curve, otherZKParam, x, G, H)
+		commitment := func(p DLEQProof) kyber.Point {
+			return curve.Point().Add(curve.Point().Mul(p.Response, G), curve.Point().Mul(p.Challenge, xG))
+		}
+		if commitment(proof).Equal(commitment(otherProof)) {
+			t.Errorf("Proofs for different zkParams share the commitment %v", commitment(proof))
+		}
+	})
+
+	for _, bad := range badProofs {
+		t.Run(bad.name, func(t *testing.T) {
+			err := verifyDLEQ(curve, bad.zkParam, G, H, bad.xG, bad.xH, DLEQProof{bad.challenge, bad.res})
+			if reflect.TypeOf(err) != reflect.TypeOf(InvalidProofError{}) {
+				t.Errorf("Got unexpected error for invalid proof: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/dedis/kyber"
+)
+
+// messageEncodingVersion is the version byte prefixed to every binary encoded message
+const messageEncodingVersion byte = 1
+
+// binaryMessage is implemented by messages which can encode their payload with the binary wire format
+type binaryMessage interface {
+	Message
+	header() *Header
+	marshalPayload(w *bytes.Buffer) error
+	unmarshalPayload(r *bytes.Reader, group kyber.Group) error
+}
+
+// newBinaryMessage constructs an empty message of the given type for decoding
+func newBinaryMessage(t MessageType) (binaryMessage, error) {
+	switch t {
+	case SecretSharesMessageType:
+		return &SecretSharesMessage{}, nil
+	case VerificationPointsMessageType:
+		return &VerificationPointsMessage{}, nil
+	case ComplaintMessageType:
+		return &ComplaintMessage{}, nil
+	case JustificationMessageType:
+		return &JustificationMessage{}, nil
+	case PublicCoefficientsMessageType:
+		return &PublicCoefficientsMessage{}, nil
+	case ReconstructionShareMessageType:
+		return &ReconstructionShareMessage{}, nil
+	case SignedMessageType:
+		return &SignedMessage{}, nil
+	case EncryptedSharesMessageType:
+		return &EncryptedSharesMessage{}, nil
+	case VerifiableComplaintMessageType:
+		return &VerifiableComplaintMessage{}, nil
+	case PublicKeyPartMessageType:
+		return &PublicKeyPartMessage{}, nil
+	}
+	return nil, UnknownMessageTypeError{t}
+}
+
+// marshalMessage encodes a message as its version, type tag, header and payload. Points and scalars
+// use the curve's native encoding.
+func marshalMessage(m binaryMessage) ([]byte, error) {
+	h := m.header()
+	w := new(bytes.Buffer)
+	w.WriteByte(messageEncodingVersion)
+	w.WriteByte(byte(m.Type()))
+
+	if err := writeScalar(w, h.SenderID); err != nil {
+		return nil, err
+	}
+	if err := writeBytes(w, h.SessionID); err != nil {
+		return nil, err
+	}
+	if err := m.marshalPayload(w); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// UnmarshalMessage decodes a binary encoded message, rebuilding its points and scalars with the given group.
+// Points which are not on the group's curve are rejected.
+//
+// Messages implement encoding.BinaryMarshaler but not encoding.BinaryUnmarshaler: decoding needs the group
+// to rebuild points and scalars, which UnmarshalBinary can't be given, and the type of a message isn't known
+// until its tag has been read.
+func UnmarshalMessage(data []byte, group kyber.Group) (Message, error) {
+	r := bytes.NewReader(data)
+
+	version, err := r.ReadByte()
+	if err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	if version != messageEncodingVersion {
+		return nil, UnsupportedMessageVersionError{version}
+	}
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+
+	m, err := newBinaryMessage(MessageType(tag))
+	if err != nil {
+		return nil, err
+	}
+
+	h := m.header()
+	if h.SenderID, err = readScalar(r, group); err != nil {
+		return nil, err
+	}
+	if h.SessionID, err = readBytes(r); err != nil {
+		return nil, err
+	}
+	if err := m.unmarshalPayload(r, group); err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, InvalidMessageEncodingError{errTrailingBytes}
+	}
+
+	return m, nil
+}
+
+// header gives the binary encoding access to a message's header
+func (h *Header) header() *Header {
+	return h
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *SecretSharesMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *SecretSharesMessage) marshalPayload(w *bytes.Buffer) error {
+	return writeScalars(w, m.RecipientID, m.SecretShare1, m.SecretShare2)
+}
+
+func (m *SecretSharesMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) error {
+	return readScalars(r, group, &m.RecipientID, &m.SecretShare1, &m.SecretShare2)
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *VerificationPointsMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *VerificationPointsMessage) marshalPayload(w *bytes.Buffer) error {
+	return writePointTuple(w, m.VerificationPoints)
+}
+
+func (m *VerificationPointsMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) (err error) {
+	m.VerificationPoints, err = readPointTuple(r, group)
+	return err
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *ComplaintMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *ComplaintMessage) marshalPayload(w *bytes.Buffer) error {
+	c := m.Complaint
+	if err := writeScalars(w, c.AccuserID, c.AccusedID); err != nil {
+		return err
+	}
+	// a dealer may not have delivered any shares to the accuser
+	if err := writeOptionalScalar(w, c.SecretShare1); err != nil {
+		return err
+	}
+	return writeOptionalScalar(w, c.SecretShare2)
+}
+
+func (m *ComplaintMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) (err error) {
+	c := &m.Complaint
+	if err = readScalars(r, group, &c.AccuserID, &c.AccusedID); err != nil {
+		return err
+	}
+	if c.SecretShare1, err = readOptionalScalar(r, group); err != nil {
+		return err
+	}
+	c.SecretShare2, err = readOptionalScalar(r, group)
+	return err
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *JustificationMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *JustificationMessage) marshalPayload(w *bytes.Buffer) error {
+	j := m.Justification
+	return writeScalars(w, j.DealerID, j.AccuserID, j.SecretShare1, j.SecretShare2)
+}
+
+func (m *JustificationMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) error {
+	j := &m.Justification
+	return readScalars(r, group, &j.DealerID, &j.AccuserID, &j.SecretShare1, &j.SecretShare2)
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *PublicCoefficientsMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *PublicCoefficientsMessage) marshalPayload(w *bytes.Buffer) error {
+	return writePointTuple(w, m.PublicCoefficients)
+}
+
+func (m *PublicCoefficientsMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) (err error) {
+	m.PublicCoefficients, err = readPointTuple(r, group)
+	return err
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *ReconstructionShareMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *ReconstructionShareMessage) marshalPayload(w *bytes.Buffer) error {
+	rs := m.ReconstructionShare
+	return writeScalars(w, rs.DealerID, rs.HolderID, rs.SecretShare1, rs.SecretShare2)
+}
+
+func (m *ReconstructionShareMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) error {
+	rs := &m.ReconstructionShare
+	return readScalars(r, group, &rs.DealerID, &rs.HolderID, &rs.SecretShare1, &rs.SecretShare2)
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *SignedMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *SignedMessage) marshalPayload(w *bytes.Buffer) error {
+	if err := writeLongBytes(w, m.Payload); err != nil {
+		return err
+	}
+	return writeBytes(w, m.Signature)
+}
+
+func (m *SignedMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) (err error) {
+	if m.Payload, err = readLongBytes(r); err != nil {
+		return err
+	}
+	m.Signature, err = readBytes(r)
+	return err
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *EncryptedSharesMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *EncryptedSharesMessage) marshalPayload(w *bytes.Buffer) error {
+	if err := writeScalar(w, m.RecipientID); err != nil {
+		return err
+	}
+	return writeBytes(w, m.Ciphertext)
+}
+
+func (m *EncryptedSharesMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) (err error) {
+	if m.RecipientID, err = readScalar(r, group); err != nil {
+		return err
+	}
+	m.Ciphertext, err = readBytes(r)
+	return err
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *VerifiableComplaintMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *VerifiableComplaintMessage) marshalPayload(w *bytes.Buffer) error {
+	vc := m.Complaint
+	if err := writeScalars(w, vc.AccuserID, vc.AccusedID); err != nil {
+		return err
+	}
+	if err := writeBytes(w, vc.Ciphertext); err != nil {
+		return err
+	}
+	if err := writePoint(w, vc.SharedKey); err != nil {
+		return err
+	}
+	return writeScalars(w, vc.Proof.Challenge, vc.Proof.Response)
+}
+
+func (m *VerifiableComplaintMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) (err error) {
+	vc := &m.Complaint
+	if err = readScalars(r, group, &vc.AccuserID, &vc.AccusedID); err != nil {
+		return err
+	}
+	if vc.Ciphertext, err = readBytes(r); err != nil {
+		return err
+	}
+	if vc.SharedKey, err = readPoint(r, group); err != nil {
+		return err
+	}
+	return readScalars(r, group, &vc.Proof.Challenge, &vc.Proof.Response)
+}
+
+// MarshalBinary encodes the message with the binary wire format
+func (m *PublicKeyPartMessage) MarshalBinary() ([]byte, error) {
+	return marshalMessage(m)
+}
+
+func (m *PublicKeyPartMessage) marshalPayload(w *bytes.Buffer) error {
+	if err := writePoint(w, m.PublicKeyPart); err != nil {
+		return err
+	}
+	if err := writePoint(w, m.Proof.Commitment); err != nil {
+		return err
+	}
+	return writeScalar(w, m.Proof.Response)
+}
+
+func (m *PublicKeyPartMessage) unmarshalPayload(r *bytes.Reader, group kyber.Group) (err error) {
+	if m.PublicKeyPart, err = readPoint(r, group); err != nil {
+		return err
+	}
+	if m.Proof.Commitment, err = readPoint(r, group); err != nil {
+		return err
+	}
+	m.Proof.Response, err = readScalar(r, group)
+	return err
+}
+
+// writeScalar appends a scalar's native encoding
+func writeScalar(w *bytes.Buffer, s kyber.Scalar) error {
+	if s == nil {
+		return InvalidMessageEncodingError{errMissingValue}
+	}
+	_, err := s.MarshalTo(w)
+	return err
+}
+
+// writeScalars appends the native encoding of each scalar in order
+func writeScalars(w *bytes.Buffer, scalars ...kyber.Scalar) error {
+	for _, s := range scalars {
+		if err := writeScalar(w, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOptionalScalar appends a presence flag followed by the scalar's native encoding if it is set
+func writeOptionalScalar(w *bytes.Buffer, s kyber.Scalar) error {
+	if s == nil {
+		return w.WriteByte(0)
+	}
+	w.WriteByte(1)
+	return writeScalar(w, s)
+}
+
+// writePointTuple appends the number of points followed by each point's native encoding
+func writePointTuple(w *bytes.Buffer, pt PointTuple) error {
+	if len(pt) > math.MaxUint16 {
+		return InvalidMessageEncodingError{errTooLong}
+	}
+	binary.Write(w, binary.BigEndian, uint16(len(pt)))
+	for _, p := range pt {
+		if p == nil {
+			return InvalidMessageEncodingError{errMissingValue}
+		}
+		if _, err := p.MarshalTo(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writePoint appends a point's native encoding
+func writePoint(w *bytes.Buffer, p kyber.Point) error {
+	if p == nil {
+		return InvalidMessageEncodingError{errMissingValue}
+	}
+	_, err := p.MarshalTo(w)
+	return err
+}
+
+// writeBytes appends the length of a byte slice followed by its contents
+func writeBytes(w *bytes.Buffer, b []byte) error {
+	if len(b) > math.MaxUint16 {
+		return InvalidMessageEncodingError{errTooLong}
+	}
+	binary.Write(w, binary.BigEndian, uint16(len(b)))
+	w.Write(b)
+	return nil
+}
+
+// writeLongBytes appends the length of a byte slice as a 32 bit integer followed by its contents
+func writeLongBytes(w *bytes.Buffer, b []byte) error {
+	if uint64(len(b)) > math.MaxUint32 {
+		return InvalidMessageEncodingError{errTooLong}
+	}
+	binary.Write(w, binary.BigEndian, uint32(len(b)))
+	w.Write(b)
+	return nil
+}
+
+// readScalar decodes a scalar with the given group's native encoding
+func readScalar(r io.Reader, group kyber.Group) (kyber.Scalar, error) {
+	s := group.Scalar()
+	if _, err := s.UnmarshalFrom(r); err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	return s, nil
+}
+
+// readScalars decodes scalars in order into the given destinations
+func readScalars(r io.Reader, group kyber.Group, scalars ...*kyber.Scalar) (err error) {
+	for _, s := range scalars {
+		if *s, err = readScalar(r, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readOptionalScalar decodes a presence flag followed by a scalar if it is set
+func readOptionalScalar(r *bytes.Reader, group kyber.Group) (kyber.Scalar, error) {
+	present, err := r.ReadByte()
+	if err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	switch present {
+	case 0:
+		return nil, nil
+	case 1:
+		return readScalar(r, group)
+	}
+	return nil, InvalidMessageEncodingError{errInvalidFlag}
+}
+
+// readPointTuple decodes the number of points followed by each point, rejecting points which are
+// not on the group's curve
+func readPointTuple(r io.Reader, group kyber.Group) (PointTuple, error) {
+	var count uint16
+	if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	pt := make(PointTuple, count)
+	for i := range pt {
+		pt[i] = group.Point()
+		if _, err := pt[i].UnmarshalFrom(r); err != nil {
+			return nil, InvalidMessageEncodingError{err}
+		}
+	}
+	return pt, nil
+}
+
+// readPoint decodes a point with the given group's native encoding, rejecting points which are not on the
+// group's curve
+func readPoint(r io.Reader, group kyber.Group) (kyber.Point, error) {
+	p := group.Point()
+	if _, err := p.UnmarshalFrom(r); err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	return p, nil
+}
+
+// readBytes decodes the length of a byte slice followed by its contents
+func readBytes(r io.Reader) ([]byte, error) {
+	var length uint16
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	return b, nil
+}
+
+// readLongBytes decodes the length of a byte slice as a 32 bit integer followed by its contents
+func readLongBytes(r *bytes.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	if int64(length) > int64(r.Len()) {
+		return nil, InvalidMessageEncodingError{io.ErrUnexpectedEOF}
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, InvalidMessageEncodingError{err}
+	}
+	return b, nil
+}
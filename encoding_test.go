@@ -0,0 +1,124 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding"
+	"reflect"
+	"testing"
+)
+
+// getMessagesForTesting returns one message of every type sent by the given node
+func getMessagesForTesting(n *Node) []Message {
+	curve := n.curve
+	header := Header{n.id, []byte("session")}
+	otherID := curve.Scalar().SetInt64(2)
+	share1, share2 := n.EvaluatePolynomials(otherID)
+	_, sharedKey, proof := proveDLEQ(curve, n.zkParam, share1, curve.Point().Base(), curve.Point().Base())
+
+	return []Message{
+		&SecretSharesMessage{header, otherID, share1, share2},
+		&VerificationPointsMessage{header, n.VerificationPoints()},
+		&ComplaintMessage{header, Complaint{n.id, otherID, share1, share2}},
+		&ComplaintMessage{header, Complaint{n.id, otherID, nil, nil}},
+		&JustificationMessage{header, Justification{n.id, otherID, share1, share2}},
+		&PublicCoefficientsMessage{header, n.PublicCoefficients()},
+		&ReconstructionShareMessage{header, ReconstructionShare{otherID, n.id, share1, share2}},
+		&SignedMessage{header, []byte("payload"), []byte("signature")},
+		&EncryptedSharesMessage{header, otherID, []byte("ciphertext")},
+		&VerifiableComplaintMessage{header, VerifiableComplaint{n.id, otherID, []byte("ciphertext"), sharedKey, proof}},
+		&PublicKeyPartMessage{header, n.PublicKeyPart(), n.PublicKeyPartProof(header.SessionID)},
+	}
+}
+
+// messagesEqual compares two messages by their binary encoding
+func messagesEqual(a, b Message) bool {
+	encodedA, errA := a.(encoding.BinaryMarshaler).MarshalBinary()
+	encodedB, errB := b.(encoding.BinaryMarshaler).MarshalBinary()
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+func TestMessageBinaryEncoding(t *testing.T) {
+	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+
+	node, err := NewNode(
+		curve, g2, zkParam, timeout,
+		id, secretPoly1, secretPoly2,
+	)
+	if node == nil || err != nil {
+		t.Fatalf("Could not create new node: %v", err)
+	}
+
+	for _, m := range getMessagesForTesting(node) {
+		t.Run(m.Type().String(), func(t *testing.T) {
+			data, err := m.(encoding.BinaryMarshaler).MarshalBinary()
+			if err != nil {
+				t.Fatalf("Could not encode message %v: %v", m, err)
+			}
+			if data[0] != messageEncodingVersion || MessageType(data[1]) != m.Type() {
+				t.Errorf("Got unexpected version and type tag %v", data[:2])
+			}
+
+			decoded, err := UnmarshalMessage(data, curve)
+			if err != nil {
+				t.Fatalf("Could not decode message %v: %v", m, err)
+			}
+			if reflect.TypeOf(decoded) != reflect.TypeOf(m) ||
+				!decoded.Sender().Equal(m.Sender()) ||
+				!bytes.Equal(decoded.Session(), m.Session()) ||
+				!messagesEqual(decoded, m) {
+				t.Errorf("Decoded message doesn't match:\nexpected: %v\nactual: %v", m, decoded)
+			}
+
+			if _, err := UnmarshalMessage(data[:len(data)-1], curve); err == nil {
+				t.Errorf("Decoded truncated message")
+			}
+			if _, err := UnmarshalMessage(append(data, 0), curve); err == nil {
+				t.Errorf("Decoded message with trailing bytes")
+			}
+		})
+	}
+
+	t.Run("Invalid version and type", func(t *testing.T) {
+		data, _ := (&VerificationPointsMessage{Header{id, nil}, node.VerificationPoints()}).MarshalBinary()
+
+		badVersion := append([]byte{}, data...)
+		badVersion[0] = messageEncodingVersion + 1
+		if _, err := UnmarshalMessage(badVersion, curve); reflect.TypeOf(err) != reflect.TypeOf(UnsupportedMessageVersionError{}) {
+			t.Errorf("Got unexpected error for unsupported version: %v", err)
+		}
+
+		badType := append([]byte{}, data...)
+		badType[1] = 0xff
+		if _, err := UnmarshalMessage(badType, curve); reflect.TypeOf(err) != reflect.TypeOf(UnknownMessageTypeError{}) {
+			t.Errorf("Got unexpected error for unknown type: %v", err)
+		}
+	})
+
+	t.Run("Point not on curve", func(t *testing.T) {
+		data, _ := (&VerificationPointsMessage{Header{id, nil}, PointTuple{curve.Point().Base()}}).MarshalBinary()
+
+		// (1, 1) has coordinates within the field but doesn't satisfy y^2 = x^3 + 3
+		point := data[len(data)-curve.PointLen():]
+		for i := range point {
+			point[i] = 0
+		}
+		point[len(point)/2-1], point[len(point)-1] = 1, 1
+		if _, err := UnmarshalMessage(data, curve); reflect.TypeOf(err) != reflect.TypeOf(InvalidMessageEncodingError{}) {
+			t.Errorf("Got unexpected error for point not on curve: %v", err)
+		}
+	})
+
+	t.Run("Missing values", func(t *testing.T) {
+		bad := []encoding.BinaryMarshaler{
+			&SecretSharesMessage{Header{nil, nil}, id, id, id},
+			&JustificationMessage{Header{id, nil}, Justification{id, nil, id, id}},
+			&PublicCoefficientsMessage{Header{id, nil}, PointTuple{nil}},
+			&VerificationPointsMessage{Header{id, make([]byte, 1<<16)}, nil},
+		}
+		for _, m := range bad {
+			if _, err := m.MarshalBinary(); err == nil {
+				t.Errorf("Encoded message with missing values %v", m)
+			}
+		}
+	})
+}
@@ -0,0 +1,254 @@
+package dkg
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/dedis/kyber"
+)
+
+// shareCipher derives the AEAD protecting the secret shares a dealer sends to a recipient from the ECDH shared
+// key of the dealer's ephemeral key and the recipient's identity key, along with the additional data binding a
+// ciphertext to the session, dealer and recipient.
+func shareCipher(
+	sharedKey, ephemeralKey kyber.Point,
+	sessionID []byte,
+	dealerID, recipientID kyber.Scalar,
+) (cipher.AEAD, []byte, error) {
+	ad := hashParts([]byte("dkg share encryption"), sessionID, marshalAll(dealerID, recipientID))
+
+	h := sha256.New()
+	h.Write(marshalAll(sharedKey, ephemeralKey))
+	h.Write(ad)
+
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, ad, nil
+}
+
+// encryptSecretShares encrypts a pair of secret shares for the holder of an identity key with a fresh ephemeral
+// key. The returned ciphertext is prefixed with the ephemeral public key and the nonce.
+func encryptSecretShares(
+	group kyber.Group,
+	recipientKey kyber.Point,
+	sessionID []byte,
+	dealerID, recipientID kyber.Scalar,
+	share1, share2 kyber.Scalar,
+) ([]byte, error) {
+	ephemeral := group.Scalar().Pick(randomStream{})
+	ephemeralKey := group.Point().Mul(ephemeral, nil)
+	sharedKey := group.Point().Mul(ephemeral, recipientKey)
+
+	aead, ad, err := shareCipher(sharedKey, ephemeralKey, sessionID, dealerID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	prefix := append(marshalAll(ephemeralKey), nonce...)
+	return aead.Seal(prefix, nonce, marshalAll(share1, share2), ad), nil
+}
+
+// ephemeralKeyOf decodes the ephemeral public key a ciphertext produced by EncryptSecretShares is prefixed with.
+func ephemeralKeyOf(group kyber.Group, dealerID, recipientID kyber.Scalar, ciphertext []byte) (kyber.Point, error) {
+	ephemeralKey := group.Point()
+	if len(ciphertext) < group.PointLen() || ephemeralKey.UnmarshalBinary(ciphertext[:group.PointLen()]) != nil {
+		return nil, ShareDecryptionError{dealerID, recipientID}
+	}
+	return ephemeralKey, nil
+}
+
+// EncryptSecretShares encrypts this node's secret shares for another node so that they may be delivered over a
+// public broadcast channel during the given session. Every ciphertext is encrypted with a fresh ephemeral key,
+// so that the shared key revealed by a verifiable complaint about it doesn't expose any other shares.
+func (n *Node) EncryptSecretShares(recipientID kyber.Scalar, sessionID []byte) ([]byte, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	recipientKey, ok := n.identityKeys[scalarKey(recipientID)]
+	if !ok {
+		return nil, MissingIdentityKeyError{recipientID}
+	}
+	share1, share2 := n.EvaluatePolynomials(recipientID)
+	return encryptSecretShares(n.curve, recipientKey, sessionID, n.id, recipientID, share1, share2)
+}
+
+// decryptSecretShares opens a ciphertext produced by EncryptSecretShares with the ECDH shared key of the
+// dealer's ephemeral key and the recipient's identity key.
+func decryptSecretShares(
+	group kyber.Group,
+	sharedKey kyber.Point,
+	sessionID []byte,
+	dealerID, recipientID kyber.Scalar,
+	ciphertext []byte,
+) (kyber.Scalar, kyber.Scalar, error) {
+	ephemeralKey, err := ephemeralKeyOf(group, dealerID, recipientID, ciphertext)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, ad, err := shareCipher(sharedKey, ephemeralKey, sessionID, dealerID, recipientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed := ciphertext[group.PointLen():]
+	if len(sealed) < aead.NonceSize() {
+		return nil, nil, ShareDecryptionError{dealerID, recipientID}
+	}
+
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], ad)
+	if err != nil || len(plaintext) != 2*group.ScalarLen() {
+		return nil, nil, ShareDecryptionError{dealerID, recipientID}
+	}
+
+	share1, share2 := group.Scalar(), group.Scalar()
+	if share1.UnmarshalBinary(plaintext[:group.ScalarLen()]) != nil ||
+		share2.UnmarshalBinary(plaintext[group.ScalarLen():]) != nil {
+		return nil, nil, ShareDecryptionError{dealerID, recipientID}
+	}
+	return share1, share2, nil
+}
+
+// DecryptSecretShares decrypts the secret shares another node encrypted for this node during the given session
+// with EncryptSecretShares.
+func (n *Node) DecryptSecretShares(dealerID kyber.Scalar, sessionID []byte, ciphertext []byte) (kyber.Scalar, kyber.Scalar, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.identityKey == nil {
+		return nil, nil, MissingIdentityKeyError{n.id}
+	}
+	ephemeralKey, err := ephemeralKeyOf(n.curve, dealerID, n.id, ciphertext)
+	if err != nil {
+		return nil, nil, err
+	}
+	sharedKey := n.curve.Point().Mul(n.identityKey, ephemeralKey)
+	return decryptSecretShares(n.curve, sharedKey, sessionID, dealerID, n.id, ciphertext)
+}
+
+// ciphertextKey identifies the encrypted secret shares a dealer broadcast for a recipient
+func ciphertextKey(dealerID, recipientID kyber.Scalar) string {
+	return scalarKey(dealerID) + scalarKey(recipientID)
+}
+
+// RecordEncryptedSecretShares keeps the encrypted secret shares a dealer broadcast for a recipient so that
+// complaints about them can later be adjudicated. Only the first ciphertext seen for a recipient is kept.
+func (n *Node) RecordEncryptedSecretShares(m *EncryptedSharesMessage) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if m.SenderID == nil || m.RecipientID == nil {
+		return InvalidMessageEncodingError{errMissingValue}
+	}
+	if n.encryptedShares == nil {
+		n.encryptedShares = make(map[string]*EncryptedSharesMessage)
+	}
+
+	key := ciphertextKey(m.SenderID, m.RecipientID)
+	if _, ok := n.encryptedShares[key]; !ok {
+		n.encryptedShares[key] = m
+	}
+	return nil
+}
+
+// VerifiableComplaint is a complaint about encrypted secret shares which reveals the ECDH shared key of the
+// accuser's identity key and the ephemeral key the dealer encrypted them with, along with a proof that the
+// accuser computed it from its identity key. Any node can decrypt the dealer's broadcast ciphertext with the
+// revealed key and check the shares itself, without learning the accuser's private key. Since the ephemeral
+// key is only used for that ciphertext, no other shares are exposed.
+type VerifiableComplaint struct {
+	// The ID of the node filing the complaint
+	AccuserID kyber.Scalar
+	// The ID of the dealer the complaint is filed against
+	AccusedID kyber.Scalar
+	// The encrypted secret shares the dealer broadcast for the accuser
+	Ciphertext []byte
+	// The ECDH shared key of the accuser's identity key and the dealer's ephemeral key
+	SharedKey kyber.Point
+	// A proof that the shared key has the same discrete logarithm relative to the dealer's ephemeral public
+	// key as the accuser's identity public key has relative to the base point
+	Proof DLEQProof
+}
+
+// VerifiableComplaint produces a verifiable complaint against a dealer whose encrypted secret shares for this
+// node don't decrypt to shares matching its verification points.
+func (n *Node) VerifiableComplaint(dealerID kyber.Scalar) (*VerifiableComplaint, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.identityKey == nil {
+		return nil, MissingIdentityKeyError{n.id}
+	}
+	m, ok := n.encryptedShares[ciphertextKey(dealerID, n.id)]
+	if !ok {
+		return nil, MissingCiphertextError{dealerID, n.id}
+	}
+	ephemeralKey, err := ephemeralKeyOf(n.curve, dealerID, n.id, m.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	_, sharedKey, proof := proveDLEQ(n.curve, n.zkParam, n.identityKey, n.curve.Point().Base(), ephemeralKey)
+	return &VerifiableComplaint{n.id, dealerID, m.Ciphertext, sharedKey, proof}, nil
+}
+
+// ProcessVerifiableComplaint checks the proof of a verifiable complaint filed by another node, then decrypts
+// the ciphertext the dealer broadcast for the accuser with the revealed shared key. The complaint is upheld
+// if the shares don't decrypt or don't match the dealer's verification points, in which case the dealer
+// is disqualified when computing the qualified set. Unfounded complaints return false.
+func (n *Node) ProcessVerifiableComplaint(vc VerifiableComplaint) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	complaint := Complaint{AccuserID: vc.AccuserID, AccusedID: vc.AccusedID}
+	if err := complaint.validate(); err != nil {
+		return false, err
+	}
+
+	accuserKey, ok := n.identityKeys[scalarKey(vc.AccuserID)]
+	if !ok {
+		return false, MissingIdentityKeyError{vc.AccuserID}
+	}
+	m, ok := n.encryptedShares[ciphertextKey(vc.AccusedID, vc.AccuserID)]
+	if !ok {
+		return false, MissingCiphertextError{vc.AccusedID, vc.AccuserID}
+	}
+	if !bytes.Equal(m.Ciphertext, vc.Ciphertext) {
+		return false, InvalidComplaintError{complaint, "ciphertext doesn't match the dealer's broadcast"}
+	}
+	ephemeralKey, err := ephemeralKeyOf(n.curve, vc.AccusedID, vc.AccuserID, m.Ciphertext)
+	if err != nil {
+		return false, err
+	}
+	err = verifyDLEQ(n.curve, n.zkParam, n.curve.Point().Base(), ephemeralKey, accuserKey, vc.SharedKey, vc.Proof)
+	if err != nil {
+		return false, err
+	}
+
+	p, err := n.getParticipantByID(vc.AccusedID)
+	if p == nil || err != nil {
+		return false, err
+	}
+
+	share1, share2, err := decryptSecretShares(n.curve, vc.SharedKey, m.SessionID, vc.AccusedID, vc.AccuserID, m.Ciphertext)
+	if err == nil && n.verifySecretShares(vc.AccuserID, share1, share2, p.verificationPoints) {
+		return false, nil
+	}
+
+	p.upheldComplaint = true
+	if p.complaintBy(vc.AccuserID) == nil {
+		complaint.SecretShare1, complaint.SecretShare2 = share1, share2
+		p.complaints = append(p.complaints, complaint)
+	}
+	return true, nil
+}
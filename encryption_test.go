@@ -0,0 +1,217 @@
+package dkg
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+// setIdentityKeysForTesting gives every node a random identity key and registers it with every other node
+func setIdentityKeysForTesting(t *testing.T, nodes []*Node) {
+	rand := bn256.NewSuite().RandomStream()
+	for _, n := range nodes {
+		if err := n.SetIdentityKey(n.curve.Scalar().Pick(rand)); err != nil {
+			t.Fatalf("Could not set identity key: %v", err)
+		}
+	}
+	for _, n := range nodes {
+		for _, other := range nodes {
+			if err := n.RegisterIdentityKey(other.id, other.IdentityPublicKey()); err != nil {
+				t.Fatalf("Could not register identity key: %v", err)
+			}
+		}
+	}
+}
+
+func TestEncryptSecretShares(t *testing.T) {
+	nodes := generateGroupForTesting(t, 3, 2)
+	dealer, recipient, eavesdropper := nodes[0], nodes[1], nodes[2]
+	session := []byte("session")
+
+	if _, err := dealer.EncryptSecretShares(recipient.id, session); reflect.TypeOf(err) != reflect.TypeOf(MissingIdentityKeyError{}) {
+		t.Errorf("Got unexpected error encrypting without identity keys: %v", err)
+	}
+
+	setIdentityKeysForTesting(t, nodes)
+
+	ciphertext, err := dealer.EncryptSecretShares(recipient.id, session)
+	if err != nil {
+		t.Fatalf("Could not encrypt secret shares: %v", err)
+	}
+
+	share1, share2, err := recipient.DecryptSecretShares(dealer.id, session, ciphertext)
+	expected1, expected2 := dealer.EvaluatePolynomials(recipient.id)
+	if err != nil || !share1.Equal(expected1) || !share2.Equal(expected2) {
+		t.Fatalf("Decrypted wrong secret shares %v, %v: %v", share1, share2, err)
+	}
+
+	t.Run("Message round trip", func(t *testing.T) {
+		m := &EncryptedSharesMessage{Header{dealer.id, session}, recipient.id, ciphertext}
+		data, _ := m.MarshalBinary()
+		decoded, err := UnmarshalMessage(data, dealer.curve)
+		if err != nil || !messagesEqual(decoded, m) {
+			t.Fatalf("Could not round trip encrypted shares message: %v", err)
+		}
+		em := decoded.(*EncryptedSharesMessage)
+		if _, _, err := recipient.DecryptSecretShares(em.SenderID, em.SessionID, em.Ciphertext); err != nil {
+			t.Errorf("Could not decrypt decoded secret shares: %v", err)
+		}
+	})
+
+	t.Run("Wrong recipient or dealer", func(t *testing.T) {
+		if _, _, err := eavesdropper.DecryptSecretShares(dealer.id, session, ciphertext); reflect.TypeOf(err) != reflect.TypeOf(ShareDecryptionError{}) {
+			t.Errorf("Got unexpected error decrypting another node's shares: %v", err)
+		}
+		if _, _, err := recipient.DecryptSecretShares(eavesdropper.id, session, ciphertext); reflect.TypeOf(err) != reflect.TypeOf(ShareDecryptionError{}) {
+			t.Errorf("Got unexpected error decrypting with wrong dealer: %v", err)
+		}
+	})
+
+	t.Run("Wrong session", func(t *testing.T) {
+		if _, _, err := recipient.DecryptSecretShares(dealer.id, []byte("other session"), ciphertext); reflect.TypeOf(err) != reflect.TypeOf(ShareDecryptionError{}) {
+			t.Errorf("Got unexpected error decrypting shares from another session: %v", err)
+		}
+	})
+
+	t.Run("Fresh ephemeral keys", func(t *testing.T) {
+		again, err := dealer.EncryptSecretShares(recipient.id, session)
+		if err != nil {
+			t.Fatalf("Could not encrypt secret shares: %v", err)
+		}
+		pointLen := dealer.curve.PointLen()
+		if bytes.Equal(again[:pointLen], ciphertext[:pointLen]) {
+			t.Errorf("Encrypted secret shares twice with the same ephemeral key")
+		}
+	})
+
+	t.Run("Tampered ciphertext", func(t *testing.T) {
+		tampered := append([]byte{}, ciphertext...)
+		tampered[len(tampered)-1] ^= 1
+		for _, bad := range [][]byte{tampered, ciphertext[:4], nil} {
+			if _, _, err := recipient.DecryptSecretShares(dealer.id, session, bad); reflect.TypeOf(err) != reflect.TypeOf(ShareDecryptionError{}) {
+				t.Errorf("Got unexpected error decrypting tampered ciphertext: %v", err)
+			}
+		}
+	})
+}
+
+// broadcastEncryptedSharesForTesting records a dealer's ciphertext for a recipient with every node
+func broadcastEncryptedSharesForTesting(t *testing.T, nodes []*Node, dealer, recipient *Node, ciphertext []byte) {
+	m := &EncryptedSharesMessage{Header{dealer.id, []byte("session")}, recipient.id, ciphertext}
+	for _, n := range nodes {
+		if err := n.RecordEncryptedSecretShares(m); err != nil {
+			t.Fatalf("Could not record encrypted secret shares: %v", err)
+		}
+	}
+}
+
+func TestVerifiableComplaint(t *testing.T) {
+	nodes := generateGroupForTesting(t, 4, 2)
+	dealer, accuser, observer := nodes[0], nodes[1], nodes[2]
+	setIdentityKeysForTesting(t, nodes)
+
+	if _, err := accuser.VerifiableComplaint(dealer.id); reflect.TypeOf(err) != reflect.TypeOf(MissingCiphertextError{}) {
+		t.Errorf("Got unexpected error filing complaint without ciphertext: %v", err)
+	}
+
+	t.Run("Honest dealer", func(t *testing.T) {
+		ciphertext, err := dealer.EncryptSecretShares(accuser.id, []byte("session"))
+		if err != nil {
+			t.Fatalf("Could not encrypt secret shares: %v", err)
+		}
+		broadcastEncryptedSharesForTesting(t, nodes, dealer, accuser, ciphertext)
+
+		vc, err := accuser.VerifiableComplaint(dealer.id)
+		if vc == nil || err != nil {
+			t.Fatalf("Could not file verifiable complaint: %v", err)
+		}
+		if upheld, err := observer.ProcessVerifiableComplaint(*vc); upheld || err != nil {
+			t.Errorf("Upheld complaint against honest dealer: %v", err)
+		}
+		if qual := observer.ComputeQUAL(); len(qual) != len(nodes) {
+			t.Errorf("Honest dealer was disqualified, got QUAL %v", qual)
+		}
+	})
+
+	t.Run("Bad shares", func(t *testing.T) {
+		badDealer := nodes[3]
+
+		// the dealer encrypts shares which don't match its verification points
+		share1, share2 := badDealer.EvaluatePolynomials(observer.id)
+		ciphertext, err := encryptSecretShares(
+			badDealer.curve, accuser.IdentityPublicKey(), []byte("session"), badDealer.id, accuser.id, share1, share2,
+		)
+		if err != nil {
+			t.Fatalf("Could not encrypt secret shares: %v", err)
+		}
+		broadcastEncryptedSharesForTesting(t, nodes, badDealer, accuser, ciphertext)
+
+		vc, err := accuser.VerifiableComplaint(badDealer.id)
+		if vc == nil || err != nil {
+			t.Fatalf("Could not file verifiable complaint: %v", err)
+		}
+
+		m := &VerifiableComplaintMessage{Header{accuser.id, nil}, *vc}
+		data, _ := m.MarshalBinary()
+		decoded, err := UnmarshalMessage(data, observer.curve)
+		if err != nil || !messagesEqual(decoded, m) {
+			t.Fatalf("Could not round trip verifiable complaint message: %v", err)
+		}
+
+		upheld, err := observer.ProcessVerifiableComplaint(decoded.(*VerifiableComplaintMessage).Complaint)
+		if !upheld || err != nil {
+			t.Fatalf("Complaint against dealer with bad shares wasn't upheld: %v", err)
+		}
+
+		// a justification doesn't clear a verifiable complaint
+		justification, err := badDealer.Justify(Complaint{accuser.id, badDealer.id, nil, nil})
+		if err != nil {
+			t.Fatalf("Could not justify complaint: %v", err)
+		}
+		observer.ProcessJustification(*justification)
+
+		qual := observer.ComputeQUAL()
+		for _, id := range qual {
+			if id.Equal(badDealer.id) {
+				t.Errorf("Dealer with bad shares is in QUAL %v", qual)
+			}
+		}
+		if len(qual) != len(nodes)-1 {
+			t.Errorf("Got unexpected QUAL %v", qual)
+		}
+	})
+
+	t.Run("Forged complaint", func(t *testing.T) {
+		vc, err := accuser.VerifiableComplaint(dealer.id)
+		if err != nil {
+			t.Fatalf("Could not file verifiable complaint: %v", err)
+		}
+
+		forged := *vc
+		forged.SharedKey = observer.curve.Point().Base()
+		if _, err := observer.ProcessVerifiableComplaint(forged); reflect.TypeOf(err) != reflect.TypeOf(InvalidProofError{}) {
+			t.Errorf("Got unexpected error for forged shared key: %v", err)
+		}
+
+		// the proof only holds for the accuser's identity key and the ephemeral key of its own ciphertext
+		other := nodes[3]
+		otherCiphertext, err := dealer.EncryptSecretShares(other.id, []byte("session"))
+		if err != nil {
+			t.Fatalf("Could not encrypt secret shares: %v", err)
+		}
+		broadcastEncryptedSharesForTesting(t, nodes, dealer, other, otherCiphertext)
+		forged = *vc
+		forged.AccuserID, forged.Ciphertext = other.id, otherCiphertext
+		if _, err := observer.ProcessVerifiableComplaint(forged); reflect.TypeOf(err) != reflect.TypeOf(InvalidProofError{}) {
+			t.Errorf("Got unexpected error for complaint claiming another accuser: %v", err)
+		}
+
+		forged = *vc
+		forged.Ciphertext = []byte("ciphertext")
+		if _, err := observer.ProcessVerifiableComplaint(forged); reflect.TypeOf(err) != reflect.TypeOf(InvalidComplaintError{}) {
+			t.Errorf("Got unexpected error for complaint with substituted ciphertext: %v", err)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package dkg
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/dedis/kyber"
@@ -79,3 +80,360 @@ type InvalidPointValueError struct {
 func (e InvalidPointValueError) Error() string {
 	return fmt.Sprintf("scalar point values: %v should not be nil", e.value)
 }
+
+// InvalidComplaintError indicates that a complaint is malformed
+type InvalidComplaintError struct {
+	complaint Complaint
+	reason    string
+}
+
+func (e InvalidComplaintError) Error() string {
+	return fmt.Sprintf("dkg: invalid complaint by %v against %v: %v",
+		e.complaint.AccuserID, e.complaint.AccusedID, e.reason,
+	)
+}
+
+// DuplicateComplaintError indicates that an accuser has already filed a complaint against a node
+type DuplicateComplaintError struct {
+	complaint Complaint
+}
+
+func (e DuplicateComplaintError) Error() string {
+	return fmt.Sprintf("dkg: duplicate complaint by %v against %v",
+		e.complaint.AccuserID, e.complaint.AccusedID,
+	)
+}
+
+// InvalidJustificationError indicates that a justification is missing required fields
+type InvalidJustificationError struct {
+	justification Justification
+}
+
+func (e InvalidJustificationError) Error() string {
+	return fmt.Sprintf("dkg: invalid justification by %v for %v",
+		e.justification.DealerID, e.justification.AccuserID,
+	)
+}
+
+// ComplaintNotFoundError indicates that no complaint by an accuser against a dealer has been received
+type ComplaintNotFoundError struct {
+	accuserID, accusedID kyber.Scalar
+}
+
+func (e ComplaintNotFoundError) Error() string {
+	return fmt.Sprintf("dkg: no complaint by %v against %v",
+		e.accuserID, e.accusedID,
+	)
+}
+
+// QUALNotComputedError indicates that a node tried to use the qualified set before computing it
+type QUALNotComputedError struct {
+	nodeID kyber.Scalar
+}
+
+func (e QUALNotComputedError) Error() string {
+	return fmt.Sprintf("dkg: node %v has not computed the qualified set", e.nodeID)
+}
+
+// MissingSecretShareError indicates that a node never received a secret share from a participant
+type MissingSecretShareError struct {
+	nodeID, participantID kyber.Scalar
+}
+
+func (e MissingSecretShareError) Error() string {
+	return fmt.Sprintf("dkg: node %v has no secret share from participant %v",
+		e.nodeID, e.participantID,
+	)
+}
+
+// MissingPublicCoefficientsError indicates that a node has no valid public coefficients for a participant
+type MissingPublicCoefficientsError struct {
+	nodeID, participantID kyber.Scalar
+}
+
+func (e MissingPublicCoefficientsError) Error() string {
+	return fmt.Sprintf("dkg: node %v has no valid public coefficients for participant %v",
+		e.nodeID, e.participantID,
+	)
+}
+
+// DuplicatePublicCoefficientsError indicates that a participant has already published its public coefficients
+type DuplicatePublicCoefficientsError struct {
+	nodeID, participantID kyber.Scalar
+}
+
+func (e DuplicatePublicCoefficientsError) Error() string {
+	return fmt.Sprintf("dkg: node %v already has public coefficients for participant %v",
+		e.nodeID, e.participantID,
+	)
+}
+
+// DuplicatePublicKeyPartError indicates that a participant has already published a proven public key part
+type DuplicatePublicKeyPartError struct {
+	nodeID, participantID kyber.Scalar
+}
+
+func (e DuplicatePublicKeyPartError) Error() string {
+	return fmt.Sprintf("dkg: node %v already has a public key part for participant %v",
+		e.nodeID, e.participantID,
+	)
+}
+
+// InvalidReconstructionShareError indicates that a reconstruction share is missing required fields
+type InvalidReconstructionShareError struct {
+	share ReconstructionShare
+}
+
+func (e InvalidReconstructionShareError) Error() string {
+	return fmt.Sprintf("dkg: invalid reconstruction share by %v for %v",
+		e.share.HolderID, e.share.DealerID,
+	)
+}
+
+// InsufficientReconstructionSharesError indicates that too few shares are known to reconstruct a dealer's secret
+type InsufficientReconstructionSharesError struct {
+	dealerID       kyber.Scalar
+	have, required int
+}
+
+func (e InsufficientReconstructionSharesError) Error() string {
+	return fmt.Sprintf("dkg: %v of %v shares required to reconstruct dealer %v",
+		e.have, e.required, e.dealerID,
+	)
+}
+
+// ParticipantDisqualifiedError indicates that a participant is not a member of a node's qualified set
+type ParticipantDisqualifiedError struct {
+	nodeID, participantID kyber.Scalar
+}
+
+func (e ParticipantDisqualifiedError) Error() string {
+	return fmt.Sprintf("dkg: participant %v is not in node %v qualified set",
+		e.participantID, e.nodeID,
+	)
+}
+
+var (
+	errMissingValue  = errors.New("missing point or scalar")
+	errTooLong       = errors.New("value too long")
+	errInvalidFlag   = errors.New("invalid presence flag")
+	errTrailingBytes = errors.New("trailing bytes")
+)
+
+// InvalidMessageEncodingError indicates that a message could not be encoded or decoded
+type InvalidMessageEncodingError struct {
+	err error
+}
+
+func (e InvalidMessageEncodingError) Error() string {
+	return fmt.Sprintf("dkg: invalid message encoding: %v", e.err)
+}
+
+// UnsupportedMessageVersionError indicates that a message was encoded with an unknown version of the wire format
+type UnsupportedMessageVersionError struct {
+	version byte
+}
+
+func (e UnsupportedMessageVersionError) Error() string {
+	return fmt.Sprintf("dkg: unsupported message encoding version %v", e.version)
+}
+
+// UnknownMessageTypeError indicates that a message has an unknown type tag
+type UnknownMessageTypeError struct {
+	messageType MessageType
+}
+
+func (e UnknownMessageTypeError) Error() string {
+	return fmt.Sprintf("dkg: unknown message type %v", e.messageType)
+}
+
+// CurveMismatchError indicates that encoded data was produced for a different curve than the one decoding it
+type CurveMismatchError struct {
+	encoded, expected string
+}
+
+func (e CurveMismatchError) Error() string {
+	return fmt.Sprintf("dkg: data encoded for curve %v can't be decoded with %v", e.encoded, e.expected)
+}
+
+// UnknownMessageTypeNameError indicates that a JSON encoded message has an unknown type name
+type UnknownMessageTypeNameError struct {
+	name string
+}
+
+func (e UnknownMessageTypeNameError) Error() string {
+	return fmt.Sprintf("dkg: unknown message type %q", e.name)
+}
+
+// DuplicateEndpointError indicates that a node with the same ID is already connected to a transport
+type DuplicateEndpointError struct {
+	id kyber.Scalar
+}
+
+func (e DuplicateEndpointError) Error() string {
+	return fmt.Sprintf("dkg: node %v is already connected", e.id)
+}
+
+// UnknownRecipientError indicates that a message was sent to a node which isn't connected to a transport
+type UnknownRecipientError struct {
+	id kyber.Scalar
+}
+
+func (e UnknownRecipientError) Error() string {
+	return fmt.Sprintf("dkg: unknown recipient %v", e.id)
+}
+
+// TransportClosedError indicates that a node tried to use a transport after closing it
+type TransportClosedError struct {
+	id kyber.Scalar
+}
+
+func (e TransportClosedError) Error() string {
+	return fmt.Sprintf("dkg: transport for node %v is closed", e.id)
+}
+
+// InvalidSignatureError indicates that a signature doesn't verify against the signer's public key
+type InvalidSignatureError struct{}
+
+func (e InvalidSignatureError) Error() string {
+	return "dkg: invalid signature"
+}
+
+// MissingIdentityKeyError indicates that no long-term identity key is known for a node
+type MissingIdentityKeyError struct {
+	id kyber.Scalar
+}
+
+func (e MissingIdentityKeyError) Error() string {
+	return fmt.Sprintf("dkg: no identity key for node %v", e.id)
+}
+
+// DuplicateIdentityKeyError indicates that a different identity key is already registered for a node
+type DuplicateIdentityKeyError struct {
+	id kyber.Scalar
+}
+
+func (e DuplicateIdentityKeyError) Error() string {
+	return fmt.Sprintf("dkg: a different identity key is already registered for node %v", e.id)
+}
+
+// SenderMismatchError indicates that a message claims to be sent by a different node than the one signing it
+type SenderMismatchError struct {
+	claimed, actual kyber.Scalar
+}
+
+func (e SenderMismatchError) Error() string {
+	return fmt.Sprintf("dkg: message claims sender %v but is from %v", e.claimed, e.actual)
+}
+
+// ShareDecryptionError indicates that encrypted secret shares could not be decrypted
+type ShareDecryptionError struct {
+	dealerID, recipientID kyber.Scalar
+}
+
+func (e ShareDecryptionError) Error() string {
+	return fmt.Sprintf("dkg: could not decrypt secret shares from %v for %v", e.dealerID, e.recipientID)
+}
+
+// InvalidProofError indicates that a zero knowledge proof doesn't verify
+type InvalidProofError struct{}
+
+func (e InvalidProofError) Error() string {
+	return "dkg: invalid proof"
+}
+
+// MissingCiphertextError indicates that a node hasn't seen the encrypted secret shares a dealer broadcast for a recipient
+type MissingCiphertextError struct {
+	dealerID, recipientID kyber.Scalar
+}
+
+func (e MissingCiphertextError) Error() string {
+	return fmt.Sprintf("dkg: no encrypted secret shares from %v for %v", e.dealerID, e.recipientID)
+}
+
+// AlreadyStartedError indicates that a node has already started the protocol
+type AlreadyStartedError struct {
+	nodeID kyber.Scalar
+}
+
+func (e AlreadyStartedError) Error() string {
+	return fmt.Sprintf("dkg: node %v has already started the protocol", e.nodeID)
+}
+
+// InvalidParticipantCountError indicates that the number of nodes in a group is too small for the threshold
+type InvalidParticipantCountError struct {
+	count, threshold int
+}
+
+func (e InvalidParticipantCountError) Error() string {
+	return fmt.Sprintf("dkg: %v participants are not enough for a threshold of %v", e.count, e.threshold)
+}
+
+// InvalidParticipantIDError indicates that a node can't take part in the protocol with a participant of the given ID
+type InvalidParticipantIDError struct {
+	nodeID, participantID kyber.Scalar
+}
+
+func (e InvalidParticipantIDError) Error() string {
+	return fmt.Sprintf("dkg: node %v can't add a participant with ID %v", e.nodeID, e.participantID)
+}
+
+// DuplicateVerificationPointsError indicates that a node already has verification points for a participant
+type DuplicateVerificationPointsError struct {
+	nodeID, participantID kyber.Scalar
+}
+
+func (e DuplicateVerificationPointsError) Error() string {
+	return fmt.Sprintf("dkg: node %v already has verification points for participant %v",
+		e.nodeID, e.participantID,
+	)
+}
+
+// DuplicateSecretSharesError indicates that a node already received secret shares from a participant
+type DuplicateSecretSharesError struct {
+	nodeID, participantID kyber.Scalar
+}
+
+func (e DuplicateSecretSharesError) Error() string {
+	return fmt.Sprintf("dkg: node %v already has secret shares from participant %v",
+		e.nodeID, e.participantID,
+	)
+}
+
+// InvalidConfigError indicates that a parameter of a Config is missing or invalid
+type InvalidConfigError struct {
+	field, reason string
+}
+
+func (e InvalidConfigError) Error() string {
+	return fmt.Sprintf("dkg: invalid config: %v %v", e.field, e.reason)
+}
+
+// DuplicateParticipantIDError indicates that more than one node of a group has the same ID
+type DuplicateParticipantIDError struct {
+	id kyber.Scalar
+}
+
+func (e DuplicateParticipantIDError) Error() string {
+	return fmt.Sprintf("dkg: more than one participant has ID %v", e.id)
+}
+
+// HashToCurveError indicates that a domain couldn't be hashed to a point of a vector space
+type HashToCurveError struct {
+	curve  kyber.Group
+	domain string
+}
+
+func (e HashToCurveError) Error() string {
+	return fmt.Sprintf("dkg: could not hash domain %q to a %v point", e.domain, e.curve)
+}
+
+// G2MismatchError indicates that a g2 was not derived from the domain it was expected to be derived from
+type G2MismatchError struct {
+	g2     kyber.Point
+	domain string
+}
+
+func (e G2MismatchError) Error() string {
+	return fmt.Sprintf("dkg: g2 %v is not derived from domain %q", e.g2, e.domain)
+}
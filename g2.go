@@ -0,0 +1,44 @@
+package dkg
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// hashablePoint is implemented by points which can hash messages onto their curve themselves, such as
+// bn256 points in recent versions of kyber
+type hashablePoint interface {
+	Hash(msg []byte) kyber.Point
+}
+
+// DeriveG2 derives a second element of the vector space from a domain-separation string by hashing it to
+// the curve, so that nobody knows the scalar k in the relation k * G = G2. The curve's points must be able
+// to hash onto the curve themselves; otherwise a HashToCurveError is returned.
+//
+// Decoding hashed bytes as a point is not used since most candidates aren't on the curve, and on curves
+// with a cofactor the decoded point may have a small order component. Picking a point with a stream seeded
+// from the domain is not used either since Pick may multiply the base point by a scalar drawn from the
+// stream, which anybody could recompute.
+func DeriveG2(curve kyber.Group, domain string) (kyber.Point, error) {
+	p, ok := curve.Point().(hashablePoint)
+	if !ok {
+		return nil, HashToCurveError{curve, domain}
+	}
+
+	g2 := p.Hash(hashParts([]byte("dkg g2"), []byte(domain)))
+	if g2 == nil || g2.Equal(curve.Point().Null()) || g2.Equal(curve.Point().Base()) {
+		return nil, HashToCurveError{curve, domain}
+	}
+	return g2, nil
+}
+
+// VerifyG2 checks that g2 is the second element of the vector space derived from a domain by DeriveG2.
+func VerifyG2(curve kyber.Group, g2 kyber.Point, domain string) error {
+	derived, err := DeriveG2(curve, domain)
+	if err != nil {
+		return err
+	}
+	if g2 == nil || !g2.Equal(derived) {
+		return G2MismatchError{g2, domain}
+	}
+	return nil
+}
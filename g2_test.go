@@ -0,0 +1,69 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeriveG2(t *testing.T) {
+	curve, _, _, _, _, _, _ := getValidNodeParamsForTesting(t)
+
+	g2, err := DeriveG2(curve, "dkg test")
+	if g2 == nil || err != nil {
+		t.Fatalf("Could not derive g2: %v", err)
+	}
+	if g2.Equal(curve.Point().Null()) || g2.Equal(curve.Point().Base()) {
+		t.Errorf("Derived trivial g2 %v", g2)
+	}
+	if again, _ := DeriveG2(curve, "dkg test"); !again.Equal(g2) {
+		t.Errorf("Derived different g2 for the same domain: %v, %v", g2, again)
+	}
+	if other, _ := DeriveG2(curve, "another domain"); other.Equal(g2) {
+		t.Errorf("Derived the same g2 for different domains")
+	}
+
+	if err := VerifyG2(curve, g2, "dkg test"); err != nil {
+		t.Errorf("Could not verify derived g2: %v", err)
+	}
+	chosen := curve.Point().Mul(curve.Scalar().SetInt64(42), nil)
+	if err := VerifyG2(curve, chosen, "dkg test"); reflect.TypeOf(err) != reflect.TypeOf(G2MismatchError{}) {
+		t.Errorf("Got unexpected error verifying g2 with known discrete log: %v", err)
+	}
+}
+
+func TestNewNodeG2Domain(t *testing.T) {
+	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+
+	n, err := NewNode(curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2, "dkg test")
+	if n == nil || err != nil {
+		t.Errorf("Could not create node with g2 derived from domain: %v", err)
+	}
+
+	chosen := curve.Point().Mul(curve.Scalar().SetInt64(42), nil)
+	_, err = NewNode(curve, chosen, zkParam, timeout, id, secretPoly1, secretPoly2, "dkg test")
+	if reflect.TypeOf(err) != reflect.TypeOf(G2MismatchError{}) {
+		t.Errorf("Got unexpected error creating node with g2 not derived from domain: %v", err)
+	}
+}
+
+func TestConfigG2Domain(t *testing.T) {
+	curve, g2, _, _, _, _, _ := getValidNodeParamsForTesting(t)
+
+	n, err := New(append(getValidConfigForTesting(t), WithG2(nil), WithG2Domain("dkg test"))...)
+	if n == nil || err != nil {
+		t.Fatalf("Could not create node with g2 derived from domain: %v", err)
+	}
+	if !n.g2.Equal(g2) {
+		t.Errorf("Node has g2 %v rather than %v", n.g2, g2)
+	}
+
+	if _, err := New(append(getValidConfigForTesting(t), WithG2Domain("dkg test"))...); err != nil {
+		t.Errorf("Could not create node with matching g2: %v", err)
+	}
+
+	chosen := curve.Point().Mul(curve.Scalar().SetInt64(42), nil)
+	_, err = New(append(getValidConfigForTesting(t), WithG2(chosen), WithG2Domain("dkg test"))...)
+	if reflect.TypeOf(err) != reflect.TypeOf(G2MismatchError{}) {
+		t.Errorf("Got unexpected error creating node with mismatched g2: %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package dkg
+
+import (
+	"encoding"
+	"sync"
+
+	"github.com/dedis/kyber"
+)
+
+// SetIdentityKey sets the long-term private key this node signs its messages with.
+func (n *Node) SetIdentityKey(private kyber.Scalar) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if private == nil || private.Equal(n.curve.Scalar().Zero()) {
+		return InvalidCurveScalarError{n.curve, private}
+	}
+	n.identityKey = private
+	return n.registerIdentityKey(n.id, n.ScalarBaseMult(private))
+}
+
+// IdentityPublicKey returns the long-term public key other nodes verify this node's messages with.
+func (n *Node) IdentityPublicKey() kyber.Point {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.identityKey == nil {
+		return nil
+	}
+	return n.ScalarBaseMult(n.identityKey)
+}
+
+// RegisterIdentityKey binds a node ID to the long-term public key its messages must be signed with.
+// A node's key may not be changed once registered.
+func (n *Node) RegisterIdentityKey(id kyber.Scalar, public kyber.Point) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.registerIdentityKey(id, public)
+}
+
+func (n *Node) registerIdentityKey(id kyber.Scalar, public kyber.Point) error {
+	if public == nil || public.Equal(n.curve.Point().Null()) {
+		return InvalidCurvePointError{n.curve, public}
+	}
+	if n.identityKeys == nil {
+		n.identityKeys = make(map[string]kyber.Point)
+	}
+
+	key := scalarKey(id)
+	if registered, ok := n.identityKeys[key]; ok && !registered.Equal(public) {
+		return DuplicateIdentityKeyError{id}
+	}
+	n.identityKeys[key] = public
+	return nil
+}
+
+// SignMessage signs a message sent by this node with its long-term identity key.
+func (n *Node) SignMessage(m Message) (*SignedMessage, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.identityKey == nil {
+		return nil, MissingIdentityKeyError{n.id}
+	}
+	if m.Sender() == nil || !m.Sender().Equal(n.id) {
+		return nil, SenderMismatchError{m.Sender(), n.id}
+	}
+
+	marshaler, ok := m.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, UnknownMessageTypeError{m.Type()}
+	}
+	payload, err := marshaler.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := schnorrSign(n.curve, n.identityKey, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedMessage{Header{m.Sender(), m.Session()}, payload, signature}, nil
+}
+
+// VerifyMessage checks that a signed message was signed by the registered identity key of the node it claims
+// to be sent by, returning the wrapped message.
+func (n *Node) VerifyMessage(sm *SignedMessage) (Message, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	m, err := UnmarshalMessage(sm.Payload, n.curve)
+	if err != nil {
+		return nil, err
+	}
+	if _, nested := m.(*SignedMessage); nested {
+		return nil, UnknownMessageTypeError{m.Type()}
+	}
+	if sm.SenderID == nil || !sm.SenderID.Equal(m.Sender()) {
+		return nil, SenderMismatchError{sm.SenderID, m.Sender()}
+	}
+
+	public, ok := n.identityKeys[scalarKey(m.Sender())]
+	if !ok {
+		return nil, MissingIdentityKeyError{m.Sender()}
+	}
+	if err := schnorrVerify(n.curve, public, sm.Payload, sm.Signature); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// authenticatedTransport signs every message sent through another Transport with a node's identity key,
+// and only delivers received messages carrying a valid signature by their sender.
+type authenticatedTransport struct {
+	inner Transport
+	node  *Node
+
+	out  chan Message
+	done chan struct{}
+	once sync.Once
+}
+
+// NewAuthenticatedTransport wraps a Transport so that messages are signed with the node's long-term identity
+// key when sent and verified against the sender's registered identity key when received. Received messages
+// which aren't signed, or whose signature doesn't match their sender ID, are dropped.
+func NewAuthenticatedTransport(inner Transport, n *Node) Transport {
+	t := &authenticatedTransport{
+		inner: inner,
+		node:  n,
+		out:   make(chan Message),
+		done:  make(chan struct{}),
+	}
+	go t.verify()
+	return t
+}
+
+func (t *authenticatedTransport) Send(to kyber.Scalar, m Message) error {
+	sm, err := t.node.SignMessage(m)
+	if err != nil {
+		return err
+	}
+	return t.inner.Send(to, sm)
+}
+
+func (t *authenticatedTransport) Broadcast(m Message) error {
+	sm, err := t.node.SignMessage(m)
+	if err != nil {
+		return err
+	}
+	return t.inner.Broadcast(sm)
+}
+
+func (t *authenticatedTransport) Receive() <-chan Message {
+	return t.out
+}
+
+func (t *authenticatedTransport) Close() error {
+	t.once.Do(func() { close(t.done) })
+	return t.inner.Close()
+}
+
+// verify forwards messages with valid signatures from the inner transport
+func (t *authenticatedTransport) verify() {
+	defer close(t.out)
+	for {
+		var received Message
+		var ok bool
+		select {
+		case received, ok = <-t.inner.Receive():
+			if !ok {
+				return
+			}
+		case <-t.done:
+			return
+		}
+
+		sm, signed := received.(*SignedMessage)
+		if !signed {
+			continue
+		}
+		m, err := t.node.VerifyMessage(sm)
+		if err != nil {
+			continue
+		}
+
+		select {
+		case t.out <- m:
+		case <-t.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+func TestSchnorrSignatures(t *testing.T) {
+	curve, _, _, _, _, _, _ := getValidNodeParamsForTesting(t)
+	rand := bn256.NewSuite().RandomStream()
+
+	private := curve.Scalar().Pick(rand)
+	public := curve.Point().Mul(private, nil)
+	msg := []byte("message")
+
+	sig, err := schnorrSign(curve, private, msg)
+	if err != nil {
+		t.Fatalf("Could not sign message: %v", err)
+	}
+	if err := schnorrVerify(curve, public, msg, sig); err != nil {
+		t.Errorf("Could not verify valid signature: %v", err)
+	}
+
+	other := curve.Point().Mul(curve.Scalar().Pick(rand), nil)
+	bad := []struct {
+		public kyber.Point
+		msg    []byte
+		sig    []byte
+	}{
+		{other, msg, sig},
+		{public, []byte("other message"), sig},
+		{public, msg, sig[1:]},
+		{public, msg, append(append([]byte{}, sig[:len(sig)-1]...), sig[len(sig)-1]^1)},
+	}
+	for _, b := range bad {
+		if err := schnorrVerify(curve, b.public, b.msg, b.sig); err == nil {
+			t.Errorf("Verified invalid signature %x over %s", b.sig, b.msg)
+		}
+	}
+}
+
+func TestSignedMessages(t *testing.T) {
+	nodes := generateGroupForTesting(t, 3, 2)
+	alice, bob, mallory := nodes[0], nodes[1], nodes[2]
+
+	vptsMessage := func(n *Node) Message {
+		return &VerificationPointsMessage{Header{n.id, []byte("session")}, n.VerificationPoints()}
+	}
+
+	if _, err := alice.SignMessage(vptsMessage(alice)); reflect.TypeOf(err) != reflect.TypeOf(MissingIdentityKeyError{}) {
+		t.Errorf("Got unexpected error signing without identity key: %v", err)
+	}
+
+	setIdentityKeysForTesting(t, nodes)
+
+	if err := alice.RegisterIdentityKey(bob.id, mallory.IdentityPublicKey()); reflect.TypeOf(err) != reflect.TypeOf(DuplicateIdentityKeyError{}) {
+		t.Errorf("Got unexpected error replacing identity key: %v", err)
+	}
+
+	t.Run("Valid signature", func(t *testing.T) {
+		m := vptsMessage(alice)
+		sm, err := alice.SignMessage(m)
+		if err != nil {
+			t.Fatalf("Could not sign message: %v", err)
+		}
+		verified, err := bob.VerifyMessage(sm)
+		if err != nil || !messagesEqual(verified, m) {
+			t.Errorf("Could not verify signed message: %v", err)
+		}
+	})
+
+	t.Run("Impersonation", func(t *testing.T) {
+		if _, err := mallory.SignMessage(vptsMessage(alice)); reflect.TypeOf(err) != reflect.TypeOf(SenderMismatchError{}) {
+			t.Errorf("Got unexpected error signing another node's message: %v", err)
+		}
+
+		// mallory signs a message claiming to be from alice with her own key
+		forged, _ := mallory.SignMessage(vptsMessage(mallory))
+		aliceSigned, _ := alice.SignMessage(vptsMessage(alice))
+		forged.Header = aliceSigned.Header
+		forged.Payload = aliceSigned.Payload
+		if _, err := bob.VerifyMessage(forged); reflect.TypeOf(err) != reflect.TypeOf(InvalidSignatureError{}) {
+			t.Errorf("Got unexpected error verifying forged message: %v", err)
+		}
+
+		// mallory relabels her own signed message as coming from alice
+		relabeled, _ := mallory.SignMessage(vptsMessage(mallory))
+		relabeled.SenderID = alice.id
+		if _, err := bob.VerifyMessage(relabeled); reflect.TypeOf(err) != reflect.TypeOf(SenderMismatchError{}) {
+			t.Errorf("Got unexpected error verifying relabeled message: %v", err)
+		}
+	})
+
+	t.Run("Authenticated transport", func(t *testing.T) {
+		hub := NewMemoryHub()
+		transports := make([]Transport, len(nodes))
+		for i, n := range nodes {
+			inner, _ := hub.Transport(n.id)
+			transports[i] = inner
+			if n != mallory {
+				transports[i] = NewAuthenticatedTransport(inner, n)
+			}
+			defer transports[i].Close()
+		}
+
+		// mallory sends an unsigned message and one signed by herself claiming to be from alice
+		transports[2].Send(bob.id, vptsMessage(alice))
+		forged, _ := mallory.SignMessage(vptsMessage(mallory))
+		forged.SenderID = alice.id
+		transports[2].Send(bob.id, forged)
+
+		m := vptsMessage(alice)
+		if err := transports[0].Send(bob.id, m); err != nil {
+			t.Fatalf("Could not send authenticated message: %v", err)
+		}
+
+		received := receiveMessages(t, transports[1], 1)[0]
+		if !received.Sender().Equal(alice.id) || !messagesEqual(received, m) {
+			t.Errorf("Received unexpected message %v", received)
+		}
+		select {
+		case extra := <-transports[1].Receive():
+			t.Errorf("Received unauthenticated message %v", extra)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
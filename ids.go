@@ -0,0 +1,64 @@
+package dkg
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// DeriveID derives a node ID from an arbitrary byte identity, such as an Ethereum address, by hashing it
+// into the scalar field of the group. Identities which hash to zero are rejected.
+func DeriveID(group kyber.Group, identity []byte) (kyber.Scalar, error) {
+	id := hashToScalar(group, []byte("dkg participant id"), identity)
+	if id.Equal(group.Scalar().Zero()) {
+		return nil, InvalidCurveScalarError{group, id}
+	}
+	return id, nil
+}
+
+// DeriveIDFromPublicKey derives a node ID from the canonical encoding of its long-term public key.
+func DeriveIDFromPublicKey(group kyber.Group, public kyber.Point) (kyber.Scalar, error) {
+	if public == nil {
+		return nil, InvalidCurvePointError{group, public}
+	}
+	encoded, err := public.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return DeriveID(group, encoded)
+}
+
+// DeriveIDs derives the IDs of every node of a group from their byte identities, in order. Identities
+// which are repeated or whose IDs collide are rejected, so the IDs are unique within the group.
+func DeriveIDs(group kyber.Group, identities [][]byte) ([]kyber.Scalar, error) {
+	ids := make([]kyber.Scalar, len(identities))
+	seen := make(map[string]bool, len(identities))
+	for i, identity := range identities {
+		id, err := DeriveID(group, identity)
+		if err != nil {
+			return nil, err
+		}
+		key := scalarKey(id)
+		if seen[key] {
+			return nil, DuplicateParticipantIDError{id}
+		}
+		seen[key] = true
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// DeriveIDsFromPublicKeys derives the IDs of every node of a group from their long-term public keys, in
+// order, rejecting collisions within the group.
+func DeriveIDsFromPublicKeys(group kyber.Group, publics []kyber.Point) ([]kyber.Scalar, error) {
+	identities := make([][]byte, len(publics))
+	for i, public := range publics {
+		if public == nil {
+			return nil, InvalidCurvePointError{group, public}
+		}
+		encoded, err := public.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		identities[i] = encoded
+	}
+	return DeriveIDs(group, identities)
+}
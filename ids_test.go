@@ -0,0 +1,66 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+func TestDeriveID(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	address := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	id, err := DeriveID(curve, address)
+	if id == nil || err != nil || id.Equal(curve.Scalar().Zero()) {
+		t.Fatalf("Could not derive ID: %v", err)
+	}
+	if again, _ := DeriveID(curve, address); !again.Equal(id) {
+		t.Errorf("Derived different IDs for the same identity: %v, %v", id, again)
+	}
+	if other, _ := DeriveID(curve, []byte{0xde, 0xad, 0xbe, 0xee}); other.Equal(id) {
+		t.Errorf("Derived the same ID for different identities")
+	}
+
+	t.Run("Public keys", func(t *testing.T) {
+		rand := bn256.NewSuite().RandomStream()
+		publics := make([]kyber.Point, 3)
+		for i := range publics {
+			publics[i] = curve.Point().Pick(rand)
+		}
+
+		ids, err := DeriveIDsFromPublicKeys(curve, publics)
+		if len(ids) != len(publics) || err != nil {
+			t.Fatalf("Could not derive IDs from public keys: %v", err)
+		}
+		for i, public := range publics {
+			id, err := DeriveIDFromPublicKey(curve, public)
+			if err != nil || !id.Equal(ids[i]) {
+				t.Errorf("Derived ID %v doesn't match %v: %v", id, ids[i], err)
+			}
+		}
+
+		if _, err := DeriveIDFromPublicKey(curve, nil); reflect.TypeOf(err) != reflect.TypeOf(InvalidCurvePointError{}) {
+			t.Errorf("Got unexpected error deriving ID from missing public key: %v", err)
+		}
+	})
+
+	t.Run("Collisions", func(t *testing.T) {
+		_, err := DeriveIDs(curve, [][]byte{address, []byte("other"), address})
+		if reflect.TypeOf(err) != reflect.TypeOf(DuplicateParticipantIDError{}) {
+			t.Errorf("Got unexpected error deriving colliding IDs: %v", err)
+		}
+	})
+
+	t.Run("Usable as config", func(t *testing.T) {
+		ids, err := DeriveIDs(curve, [][]byte{[]byte("a"), []byte("b"), []byte("c")})
+		if err != nil {
+			t.Fatalf("Could not derive IDs: %v", err)
+		}
+		opts := append(getValidConfigForTesting(t), WithID(ids[0]), WithThreshold(2), WithParticipantIDs(ids...))
+		if n, err := New(opts...); n == nil || err != nil {
+			t.Errorf("Could not create node with derived IDs: %v", err)
+		}
+	})
+}
@@ -0,0 +1,456 @@
+package dkg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/dedis/kyber"
+)
+
+// hexCodec converts points and scalars of a group to and from hex strings. The first error encountered
+// is kept so that a whole structure may be converted before checking for failure.
+type hexCodec struct {
+	group kyber.Group
+	err   error
+}
+
+func (c *hexCodec) fromScalar(s kyber.Scalar) string {
+	if s == nil {
+		return ""
+	}
+	b, err := s.MarshalBinary()
+	if err != nil {
+		c.fail(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func (c *hexCodec) toScalar(h string) kyber.Scalar {
+	if h == "" {
+		return nil
+	}
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		c.fail(err)
+		return nil
+	}
+	s := c.group.Scalar()
+	if err := s.UnmarshalBinary(b); err != nil {
+		c.fail(err)
+		return nil
+	}
+	return s
+}
+
+func (c *hexCodec) fromPoints(pt PointTuple) []string {
+	if pt == nil {
+		return nil
+	}
+	hexes := make([]string, len(pt))
+	for i, p := range pt {
+		if p == nil {
+			c.fail(errMissingValue)
+			continue
+		}
+		b, err := p.MarshalBinary()
+		if err != nil {
+			c.fail(err)
+		}
+		hexes[i] = hex.EncodeToString(b)
+	}
+	return hexes
+}
+
+func (c *hexCodec) toPoints(hexes []string) PointTuple {
+	if hexes == nil {
+		return nil
+	}
+	pt := make(PointTuple, len(hexes))
+	for i, h := range hexes {
+		b, err := hex.DecodeString(h)
+		if err != nil {
+			c.fail(err)
+			continue
+		}
+		pt[i] = c.group.Point()
+		if err := pt[i].UnmarshalBinary(b); err != nil {
+			c.fail(err)
+		}
+	}
+	return pt
+}
+
+func (c *hexCodec) toBytes(h string) []byte {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		c.fail(err)
+	}
+	return b
+}
+
+func (c *hexCodec) fail(err error) {
+	if c.err == nil {
+		c.err = InvalidMessageEncodingError{err}
+	}
+}
+
+// checkCurve ensures JSON data was produced for the same curve as the group decoding it
+func checkCurve(curve string, group kyber.Group) error {
+	if curve != group.String() {
+		return CurveMismatchError{curve, group.String()}
+	}
+	return nil
+}
+
+type jsonComplaint struct {
+	AccuserID    string `json:"accuser"`
+	AccusedID    string `json:"accused"`
+	SecretShare1 string `json:"secretShare1,omitempty"`
+	SecretShare2 string `json:"secretShare2,omitempty"`
+}
+
+func (c *hexCodec) fromComplaint(complaint Complaint) jsonComplaint {
+	return jsonComplaint{
+		c.fromScalar(complaint.AccuserID),
+		c.fromScalar(complaint.AccusedID),
+		c.fromScalar(complaint.SecretShare1),
+		c.fromScalar(complaint.SecretShare2),
+	}
+}
+
+func (c *hexCodec) toComplaint(complaint jsonComplaint) Complaint {
+	return Complaint{
+		c.toScalar(complaint.AccuserID),
+		c.toScalar(complaint.AccusedID),
+		c.toScalar(complaint.SecretShare1),
+		c.toScalar(complaint.SecretShare2),
+	}
+}
+
+type jsonJustification struct {
+	DealerID     string `json:"dealer"`
+	AccuserID    string `json:"accuser"`
+	SecretShare1 string `json:"secretShare1"`
+	SecretShare2 string `json:"secretShare2"`
+}
+
+type jsonReconstructionShare struct {
+	DealerID     string `json:"dealer"`
+	HolderID     string `json:"holder"`
+	SecretShare1 string `json:"secretShare1"`
+	SecretShare2 string `json:"secretShare2"`
+}
+
+type jsonSecretShares struct {
+	RecipientID  string `json:"recipient"`
+	SecretShare1 string `json:"secretShare1"`
+	SecretShare2 string `json:"secretShare2"`
+}
+
+type jsonSigned struct {
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+type jsonEncryptedShares struct {
+	RecipientID string `json:"recipient"`
+	Ciphertext  string `json:"ciphertext"`
+}
+
+type jsonVerifiableComplaint struct {
+	AccuserID  string `json:"accuser"`
+	AccusedID  string `json:"accused"`
+	Ciphertext string `json:"ciphertext"`
+	SharedKey  string `json:"sharedKey"`
+	Challenge  string `json:"challenge"`
+	Response   string `json:"response"`
+}
+
+type jsonPublicKeyPart struct {
+	PublicKeyPart string `json:"publicKeyPart"`
+	Commitment    string `json:"commitment"`
+	Response      string `json:"response"`
+}
+
+type jsonMessage struct {
+	Curve     string          `json:"curve"`
+	Type      string          `json:"type"`
+	SenderID  string          `json:"sender"`
+	SessionID string          `json:"session"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// MarshalMessageJSON encodes a message as JSON, with its points and scalars hex encoded and the name of
+// the group's curve included.
+func MarshalMessageJSON(m Message, group kyber.Group) ([]byte, error) {
+	c := &hexCodec{group: group}
+
+	var payload interface{}
+	switch m := m.(type) {
+	case *SecretSharesMessage:
+		payload = jsonSecretShares{
+			c.fromScalar(m.RecipientID), c.fromScalar(m.SecretShare1), c.fromScalar(m.SecretShare2),
+		}
+	case *VerificationPointsMessage:
+		payload = c.fromPoints(m.VerificationPoints)
+	case *ComplaintMessage:
+		payload = c.fromComplaint(m.Complaint)
+	case *JustificationMessage:
+		j := m.Justification
+		payload = jsonJustification{
+			c.fromScalar(j.DealerID), c.fromScalar(j.AccuserID),
+			c.fromScalar(j.SecretShare1), c.fromScalar(j.SecretShare2),
+		}
+	case *PublicCoefficientsMessage:
+		payload = c.fromPoints(m.PublicCoefficients)
+	case *ReconstructionShareMessage:
+		rs := m.ReconstructionShare
+		payload = jsonReconstructionShare{
+			c.fromScalar(rs.DealerID), c.fromScalar(rs.HolderID),
+			c.fromScalar(rs.SecretShare1), c.fromScalar(rs.SecretShare2),
+		}
+	case *SignedMessage:
+		payload = jsonSigned{hex.EncodeToString(m.Payload), hex.EncodeToString(m.Signature)}
+	case *EncryptedSharesMessage:
+		payload = jsonEncryptedShares{c.fromScalar(m.RecipientID), hex.EncodeToString(m.Ciphertext)}
+	case *VerifiableComplaintMessage:
+		vc := m.Complaint
+		var sharedKey string
+		if vc.SharedKey != nil {
+			sharedKey = c.fromPoints(PointTuple{vc.SharedKey})[0]
+		}
+		payload = jsonVerifiableComplaint{
+			c.fromScalar(vc.AccuserID), c.fromScalar(vc.AccusedID), hex.EncodeToString(vc.Ciphertext), sharedKey,
+			c.fromScalar(vc.Proof.Challenge), c.fromScalar(vc.Proof.Response),
+		}
+	case *PublicKeyPartMessage:
+		points := c.fromPoints(PointTuple{m.PublicKeyPart, m.Proof.Commitment})
+		payload = jsonPublicKeyPart{points[0], points[1], c.fromScalar(m.Proof.Response)}
+	default:
+		return nil, UnknownMessageTypeError{m.Type()}
+	}
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	jm := jsonMessage{
+		group.String(),
+		m.Type().String(),
+		c.fromScalar(m.Sender()),
+		hex.EncodeToString(m.Session()),
+		rawPayload,
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return json.Marshal(jm)
+}
+
+// UnmarshalMessageJSON decodes a message encoded with MarshalMessageJSON, rebuilding its points and scalars
+// with the given group.
+func UnmarshalMessageJSON(data []byte, group kyber.Group) (Message, error) {
+	var jm jsonMessage
+	if err := json.Unmarshal(data, &jm); err != nil {
+		return nil, err
+	}
+	if err := checkCurve(jm.Curve, group); err != nil {
+		return nil, err
+	}
+
+	c := &hexCodec{group: group}
+	header := Header{c.toScalar(jm.SenderID), c.toBytes(jm.SessionID)}
+
+	var m Message
+	var err error
+	switch jm.Type {
+	case SecretSharesMessageType.String():
+		var payload jsonSecretShares
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &SecretSharesMessage{
+			header, c.toScalar(payload.RecipientID),
+			c.toScalar(payload.SecretShare1), c.toScalar(payload.SecretShare2),
+		}
+	case VerificationPointsMessageType.String():
+		var payload []string
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &VerificationPointsMessage{header, c.toPoints(payload)}
+	case ComplaintMessageType.String():
+		var payload jsonComplaint
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &ComplaintMessage{header, c.toComplaint(payload)}
+	case JustificationMessageType.String():
+		var payload jsonJustification
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &JustificationMessage{header, Justification{
+			c.toScalar(payload.DealerID), c.toScalar(payload.AccuserID),
+			c.toScalar(payload.SecretShare1), c.toScalar(payload.SecretShare2),
+		}}
+	case PublicCoefficientsMessageType.String():
+		var payload []string
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &PublicCoefficientsMessage{header, c.toPoints(payload)}
+	case ReconstructionShareMessageType.String():
+		var payload jsonReconstructionShare
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &ReconstructionShareMessage{header, ReconstructionShare{
+			c.toScalar(payload.DealerID), c.toScalar(payload.HolderID),
+			c.toScalar(payload.SecretShare1), c.toScalar(payload.SecretShare2),
+		}}
+	case SignedMessageType.String():
+		var payload jsonSigned
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &SignedMessage{header, c.toBytes(payload.Payload), c.toBytes(payload.Signature)}
+	case EncryptedSharesMessageType.String():
+		var payload jsonEncryptedShares
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &EncryptedSharesMessage{header, c.toScalar(payload.RecipientID), c.toBytes(payload.Ciphertext)}
+	case VerifiableComplaintMessageType.String():
+		var payload jsonVerifiableComplaint
+		err = json.Unmarshal(jm.Payload, &payload)
+		m = &VerifiableComplaintMessage{header, VerifiableComplaint{
+			c.toScalar(payload.AccuserID), c.toScalar(payload.AccusedID), c.toBytes(payload.Ciphertext),
+			c.toPoints([]string{payload.SharedKey})[0],
+			DLEQProof{c.toScalar(payload.Challenge), c.toScalar(payload.Response)},
+		}}
+	case PublicKeyPartMessageType.String():
+		var payload jsonPublicKeyPart
+		err = json.Unmarshal(jm.Payload, &payload)
+		points := c.toPoints([]string{payload.PublicKeyPart, payload.Commitment})
+		m = &PublicKeyPartMessage{header, points[0], KeyProof{points[1], c.toScalar(payload.Response)}}
+	default:
+		return nil, UnknownMessageTypeNameError{jm.Type}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return m, nil
+}
+
+type jsonPointTuple struct {
+	Curve  string   `json:"curve"`
+	Points []string `json:"points"`
+}
+
+// MarshalPointTupleJSON encodes a PointTuple as JSON, with its points hex encoded and the name of the
+// group's curve included.
+func MarshalPointTupleJSON(pt PointTuple, group kyber.Group) ([]byte, error) {
+	c := &hexCodec{group: group}
+	jpt := jsonPointTuple{group.String(), c.fromPoints(pt)}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return json.Marshal(jpt)
+}
+
+// UnmarshalPointTupleJSON decodes a PointTuple encoded with MarshalPointTupleJSON.
+func UnmarshalPointTupleJSON(data []byte, group kyber.Group) (PointTuple, error) {
+	var jpt jsonPointTuple
+	if err := json.Unmarshal(data, &jpt); err != nil {
+		return nil, err
+	}
+	if err := checkCurve(jpt.Curve, group); err != nil {
+		return nil, err
+	}
+
+	c := &hexCodec{group: group}
+	pt := c.toPoints(jpt.Points)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return pt, nil
+}
+
+type jsonCurveComplaint struct {
+	Curve string `json:"curve"`
+	jsonComplaint
+}
+
+// MarshalComplaintJSON encodes a Complaint as JSON, with its scalars hex encoded and the name of the
+// group's curve included.
+func MarshalComplaintJSON(complaint Complaint, group kyber.Group) ([]byte, error) {
+	c := &hexCodec{group: group}
+	jc := jsonCurveComplaint{group.String(), c.fromComplaint(complaint)}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return json.Marshal(jc)
+}
+
+// UnmarshalComplaintJSON decodes a Complaint encoded with MarshalComplaintJSON.
+func UnmarshalComplaintJSON(data []byte, group kyber.Group) (*Complaint, error) {
+	var jc jsonCurveComplaint
+	if err := json.Unmarshal(data, &jc); err != nil {
+		return nil, err
+	}
+	if err := checkCurve(jc.Curve, group); err != nil {
+		return nil, err
+	}
+
+	c := &hexCodec{group: group}
+	complaint := c.toComplaint(jc.jsonComplaint)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &complaint, nil
+}
+
+type jsonParticipant struct {
+	Curve              string          `json:"curve"`
+	ID                 string          `json:"id"`
+	VerificationPoints []string        `json:"verificationPoints"`
+	PublicCoefficients []string        `json:"publicCoefficients,omitempty"`
+	Complaints         []jsonComplaint `json:"complaints,omitempty"`
+	Disqualified       bool            `json:"disqualified"`
+}
+
+// MarshalParticipantJSON encodes the public fields of a node's view of a participant as JSON, with its
+// points and scalars hex encoded and the name of the group's curve included. The secret shares the node
+// received from the participant are never included. A node's participants are retrieved with Node.Participants.
+func MarshalParticipantJSON(p *Participant, group kyber.Group) ([]byte, error) {
+	c := &hexCodec{group: group}
+	jp := jsonParticipant{
+		Curve:              group.String(),
+		ID:                 c.fromScalar(p.id),
+		VerificationPoints: c.fromPoints(p.verificationPoints),
+		PublicCoefficients: c.fromPoints(p.publicCoefficients),
+		Disqualified:       p.disqualified,
+	}
+	for _, complaint := range p.complaints {
+		jp.Complaints = append(jp.Complaints, c.fromComplaint(complaint))
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return json.Marshal(jp)
+}
+
+// UnmarshalParticipantJSON decodes the public fields of a participant encoded with MarshalParticipantJSON.
+func UnmarshalParticipantJSON(data []byte, group kyber.Group) (*Participant, error) {
+	var jp jsonParticipant
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return nil, err
+	}
+	if err := checkCurve(jp.Curve, group); err != nil {
+		return nil, err
+	}
+
+	c := &hexCodec{group: group}
+	p := &Participant{
+		id:                 c.toScalar(jp.ID),
+		verificationPoints: c.toPoints(jp.VerificationPoints),
+		publicCoefficients: c.toPoints(jp.PublicCoefficients),
+		disqualified:       jp.Disqualified,
+	}
+	for _, complaint := range jp.Complaints {
+		p.complaints = append(p.complaints, c.toComplaint(complaint))
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return p, nil
+}
@@ -0,0 +1,145 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMessageJSONEncoding(t *testing.T) {
+	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+
+	node, err := NewNode(
+		curve, g2, zkParam, timeout,
+		id, secretPoly1, secretPoly2,
+	)
+	if node == nil || err != nil {
+		t.Fatalf("Could not create new node: %v", err)
+	}
+
+	for _, m := range getMessagesForTesting(node) {
+		t.Run(m.Type().String(), func(t *testing.T) {
+			data, err := MarshalMessageJSON(m, curve)
+			if err != nil {
+				t.Fatalf("Could not encode message %v: %v", m, err)
+			}
+
+			var fields map[string]interface{}
+			if err := json.Unmarshal(data, &fields); err != nil {
+				t.Fatalf("Encoded message is not valid JSON: %v", err)
+			}
+			if fields["curve"] != curve.String() || fields["type"] != m.Type().String() {
+				t.Errorf("Got unexpected curve and type in %s", data)
+			}
+
+			decoded, err := UnmarshalMessageJSON(data, curve)
+			if err != nil {
+				t.Fatalf("Could not decode message %s: %v", data, err)
+			}
+			if reflect.TypeOf(decoded) != reflect.TypeOf(m) || !messagesEqual(decoded, m) {
+				t.Errorf("Decoded message doesn't match:\nexpected: %v\nactual: %v", m, decoded)
+			}
+
+			reencoded, _ := MarshalMessageJSON(decoded, curve)
+			if !bytes.Equal(data, reencoded) {
+				t.Errorf("JSON encoding doesn't round trip:\n%s\n%s", data, reencoded)
+			}
+		})
+	}
+
+	t.Run("Invalid messages", func(t *testing.T) {
+		bad := []string{
+			`{"curve":"other","type":"Complaint","sender":"","session":"","payload":{}}`,
+			`{"curve":"` + curve.String() + `","type":"Unknown","sender":"","session":"","payload":{}}`,
+			`{"curve":"` + curve.String() + `","type":"VerificationPoints","sender":"zz","session":"","payload":[]}`,
+			`{"curve":"` + curve.String() + `","type":"VerificationPoints","sender":"","session":"","payload":["ffff"]}`,
+		}
+		for _, data := range bad {
+			if m, err := UnmarshalMessageJSON([]byte(data), curve); err == nil {
+				t.Errorf("Decoded invalid message %s: %v", data, m)
+			}
+		}
+	})
+}
+
+func TestPublicDataJSONEncoding(t *testing.T) {
+	nodes := generateGroupForTesting(t, 3, 3)
+	n, dealer := nodes[0], nodes[1]
+	curve := n.curve
+
+	t.Run("PointTuple", func(t *testing.T) {
+		vpts := dealer.VerificationPoints()
+		data, err := MarshalPointTupleJSON(vpts, curve)
+		if err != nil {
+			t.Fatalf("Could not encode point tuple: %v", err)
+		}
+		decoded, err := UnmarshalPointTupleJSON(data, curve)
+		if err != nil || len(decoded) != len(vpts) || !comparePointTuples(decoded, vpts) {
+			t.Errorf("Decoded point tuple doesn't match: %v: %v", decoded, err)
+		}
+	})
+
+	complaint := Complaint{nodes[2].id, dealer.id, curve.Scalar().SetInt64(1), nil}
+
+	t.Run("Complaint", func(t *testing.T) {
+		data, err := MarshalComplaintJSON(complaint, curve)
+		if err != nil {
+			t.Fatalf("Could not encode complaint: %v", err)
+		}
+		decoded, err := UnmarshalComplaintJSON(data, curve)
+		if err != nil ||
+			!decoded.AccuserID.Equal(complaint.AccuserID) ||
+			!decoded.AccusedID.Equal(complaint.AccusedID) ||
+			!decoded.SecretShare1.Equal(complaint.SecretShare1) ||
+			decoded.SecretShare2 != nil {
+			t.Errorf("Decoded complaint doesn't match: %v: %v", decoded, err)
+		}
+	})
+
+	t.Run("Participant", func(t *testing.T) {
+		n.ReceiveComplaint(complaint)
+		n.ProcessPublicCoefficients(dealer.id, dealer.PublicCoefficients())
+		var p *Participant
+		for _, view := range n.Participants() {
+			if view.ID().Equal(dealer.id) {
+				p = view
+			}
+		}
+		if p == nil {
+			t.Fatalf("Participant %v is missing from node's participants", dealer.id)
+		}
+
+		data, err := MarshalParticipantJSON(p, curve)
+		if err != nil {
+			t.Fatalf("Could not encode participant: %v", err)
+		}
+		if bytes.Contains(data, []byte("secretShare1\":\""+(&hexCodec{group: curve}).fromScalar(p.secretShare1))) {
+			t.Errorf("Encoded participant contains secret share: %s", data)
+		}
+
+		decoded, err := UnmarshalParticipantJSON(data, curve)
+		if err != nil {
+			t.Fatalf("Could not decode participant %s: %v", data, err)
+		}
+		if !decoded.id.Equal(p.id) ||
+			!comparePointTuples(decoded.verificationPoints, p.verificationPoints) ||
+			!comparePointTuples(decoded.publicCoefficients, p.publicCoefficients) ||
+			len(decoded.complaints) != 1 ||
+			decoded.secretShare1 != nil {
+			t.Errorf("Decoded participant doesn't match: %v", decoded)
+		}
+
+		reencoded, _ := MarshalParticipantJSON(decoded, curve)
+		if !bytes.Equal(data, reencoded) {
+			t.Errorf("JSON encoding doesn't round trip:\n%s\n%s", data, reencoded)
+		}
+	})
+
+	t.Run("Curve mismatch", func(t *testing.T) {
+		data := []byte(`{"curve":"other","points":[]}`)
+		if _, err := UnmarshalPointTupleJSON(data, curve); reflect.TypeOf(err) != reflect.TypeOf(CurveMismatchError{}) {
+			t.Errorf("Got unexpected error for curve mismatch: %v", err)
+		}
+	})
+}
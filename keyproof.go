@@ -0,0 +1,95 @@
+package dkg
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// KeyProof is a non-interactive Schnorr proof of knowledge of the constant term of a dealer's first secret
+// polynomial, i.e. of the discrete logarithm of its public key part. It is bound to the dealer's ID, the
+// session and the group's zero knowledge parameter so that it can't be replayed by another dealer or in
+// another session. Requiring it keeps a dealer from choosing its public key part as a function of the
+// other dealers' parts in order to control the group public key.
+type KeyProof struct {
+	Commitment kyber.Point
+	Response   kyber.Scalar
+}
+
+// keyProofChallenge computes the Fiat-Shamir challenge of a KeyProof
+func keyProofChallenge(
+	group kyber.Group,
+	zkParam, dealerID kyber.Scalar,
+	sessionID []byte,
+	publicKeyPart, commitment kyber.Point,
+) kyber.Scalar {
+	return hashToScalar(group,
+		[]byte("dkg key proof challenge"),
+		marshalAll(zkParam, dealerID),
+		sessionID,
+		marshalAll(publicKeyPart, commitment),
+	)
+}
+
+// PublicKeyPartProof proves that this node knows the constant term of its first secret polynomial for the
+// given session. The commitment nonce is derived deterministically from the secret and the proof's context.
+func (n *Node) PublicKeyPartProof(sessionID []byte) KeyProof {
+	secret := n.secretPoly1[0]
+	k := hashToScalar(n.curve, []byte("dkg key proof nonce"), marshalAll(secret, n.zkParam, n.id), sessionID)
+	commitment := n.ScalarBaseMult(k)
+
+	c := keyProofChallenge(n.curve, n.zkParam, n.id, sessionID, n.PublicKeyPart(), commitment)
+	// s = k + c * x
+	s := n.curve.Scalar().Add(k, n.curve.Scalar().Mul(c, secret))
+	return KeyProof{commitment, s}
+}
+
+// verifyKeyProof checks a proof produced by PublicKeyPartProof for the given dealer, session and public
+// key part.
+func verifyKeyProof(
+	group kyber.Group,
+	zkParam, dealerID kyber.Scalar,
+	sessionID []byte,
+	publicKeyPart kyber.Point,
+	proof KeyProof,
+) error {
+	if publicKeyPart == nil || proof.Commitment == nil || proof.Response == nil {
+		return InvalidProofError{}
+	}
+
+	c := keyProofChallenge(group, zkParam, dealerID, sessionID, publicKeyPart, proof.Commitment)
+	// s * G == R + c * P
+	lhs := group.Point().Mul(proof.Response, nil)
+	rhs := group.Point().Add(proof.Commitment, group.Point().Mul(c, publicKeyPart))
+	if !lhs.Equal(rhs) {
+		return InvalidProofError{}
+	}
+	return nil
+}
+
+// ProcessPublicKeyPart verifies the proof of knowledge accompanying the public key part another node published
+// along with its public coefficients, and records the outcome on its participant entry. A dealer's public
+// coefficients are only used once its public key part has been proven and matches their first coefficient;
+// otherwise its contribution has to be reconstructed, just as when its public coefficients are bad. A dealer
+// may only publish its public key part once.
+func (n *Node) ProcessPublicKeyPart(id kyber.Scalar, sessionID []byte, publicKeyPart kyber.Point, proof KeyProof) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	p, err := n.getParticipantByID(id)
+	if p == nil || err != nil {
+		return false, err
+	}
+	if p.publicKeyPart != nil {
+		return false, DuplicatePublicKeyPartError{n.id, id}
+	}
+
+	if err := verifyKeyProof(n.curve, n.zkParam, id, sessionID, publicKeyPart, proof); err != nil {
+		p.invalidPublicCoefficients = true
+		return false, nil
+	}
+	p.publicKeyPart = publicKeyPart
+	if p.publicCoefficients != nil && (len(p.publicCoefficients) == 0 || !p.publicCoefficients[0].Equal(publicKeyPart)) {
+		p.invalidPublicCoefficients = true
+	}
+	p.missingPublicCoefficients = p.missingPublicCoefficients && p.publicCoefficients == nil
+	return !p.invalidPublicCoefficients, nil
+}
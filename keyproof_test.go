@@ -0,0 +1,115 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dedis/kyber"
+)
+
+func TestPublicKeyPartProof(t *testing.T) {
+	nodes := generateGroupForTesting(t, 5, 2)
+	dealer, receiver, other, observer, late := nodes[0], nodes[1], nodes[2], nodes[3], nodes[4]
+	curve := dealer.curve
+	session := []byte("session")
+
+	proof := dealer.PublicKeyPartProof(session)
+	if err := verifyKeyProof(curve, dealer.zkParam, dealer.id, session, dealer.PublicKeyPart(), proof); err != nil {
+		t.Fatalf("Could not verify valid proof: %v", err)
+	}
+
+	t.Run("Bound to context", func(t *testing.T) {
+		otherParam := curve.Scalar().Add(dealer.zkParam, curve.Scalar().One())
+		contexts := []struct {
+			name    string
+			zkParam kyber.Scalar
+			id      kyber.Scalar
+			session []byte
+		}{
+			{"Wrong zkParam", otherParam, dealer.id, session},
+			{"Wrong dealer", dealer.zkParam, other.id, session},
+			{"Wrong session", dealer.zkParam, dealer.id, []byte("other session")},
+		}
+		for _, bad := range contexts {
+			err := verifyKeyProof(curve, bad.zkParam, bad.id, bad.session, dealer.PublicKeyPart(), proof)
+			if reflect.TypeOf(err) != reflect.TypeOf(InvalidProofError{}) {
+				t.Errorf("%v: got unexpected error: %v", bad.name, err)
+			}
+		}
+	})
+
+	// forgetPublicKeyPart drops the public key part a node proved for the dealer while generating the group
+	forgetPublicKeyPart := func(n *Node) {
+		p, _ := n.getParticipantByID(dealer.id)
+		p.publicKeyPart = nil
+	}
+
+	t.Run("Rogue key", func(t *testing.T) {
+		forgetPublicKeyPart(other)
+		other.ComputeQUAL()
+
+		// the dealer claims a public key part which cancels the other dealers' parts
+		rogue := curve.Point().Sub(dealer.PublicKeyPart(), receiver.PublicKeyPart())
+		if ok, err := other.ProcessPublicKeyPart(dealer.id, session, rogue, proof); ok || err != nil {
+			t.Errorf("Accepted proof for rogue public key part: %v", err)
+		}
+
+		// the dealer's contribution is reconstructed rather than taken from its public coefficients
+		if ok, _ := other.ProcessPublicCoefficients(dealer.id, dealer.PublicCoefficients()); ok {
+			t.Errorf("Accepted public coefficients of dealer with invalid proof")
+		}
+		if shares := other.ReconstructionShares(); len(shares) != 1 || !shares[0].DealerID.Equal(dealer.id) {
+			t.Errorf("Dealer with invalid proof isn't reconstructed, got reconstruction shares %v", shares)
+		}
+	})
+
+	t.Run("Duplicate proof", func(t *testing.T) {
+		if ok, err := receiver.ProcessPublicKeyPart(dealer.id, nil, dealer.PublicKeyPart(), dealer.PublicKeyPartProof(nil)); ok || reflect.TypeOf(err) != reflect.TypeOf(DuplicatePublicKeyPartError{}) {
+			t.Errorf("Got unexpected result processing a public key part twice: %v, %v", ok, err)
+		}
+	})
+
+	t.Run("Public coefficients must match", func(t *testing.T) {
+		coefficients := dealer.PublicCoefficients()
+		coefficients[0] = curve.Point().Add(coefficients[0], curve.Point().Base())
+		if ok, err := receiver.ProcessPublicCoefficients(dealer.id, coefficients); ok || err != nil {
+			t.Errorf("Accepted public coefficients not matching the proven public key part: %v", err)
+		}
+		if ok, err := observer.ProcessPublicCoefficients(dealer.id, dealer.PublicCoefficients()); !ok || err != nil {
+			t.Errorf("Rejected valid public coefficients: %v", err)
+		}
+	})
+
+	t.Run("Missing proof", func(t *testing.T) {
+		forgetPublicKeyPart(late)
+		late.ComputeQUAL()
+		for _, n := range nodes {
+			if n != late {
+				late.ProcessPublicCoefficients(n.id, n.PublicCoefficients())
+			}
+		}
+
+		if _, err := late.GroupPublicKey(); reflect.TypeOf(err) != reflect.TypeOf(MissingPublicCoefficientsError{}) {
+			t.Errorf("Got unexpected error for group public key without proof: %v", err)
+		}
+		late.mu.Lock()
+		marked := late.markMissingPublicCoefficients()
+		late.mu.Unlock()
+		if shares := late.ReconstructionShares(); !marked || len(shares) != 1 || !shares[0].DealerID.Equal(dealer.id) {
+			t.Errorf("Dealer without proof isn't reconstructed, got reconstruction shares %v", shares)
+		}
+	})
+
+	t.Run("Message round trip", func(t *testing.T) {
+		m := &PublicKeyPartMessage{Header{dealer.id, session}, dealer.PublicKeyPart(), proof}
+		data, _ := m.MarshalBinary()
+		decoded, err := UnmarshalMessage(data, curve)
+		if err != nil || !messagesEqual(decoded, m) {
+			t.Fatalf("Could not round trip public key part message: %v", err)
+		}
+		pm := decoded.(*PublicKeyPartMessage)
+		if err := verifyKeyProof(curve, dealer.zkParam, pm.Sender(), pm.Session(), pm.PublicKeyPart, pm.Proof); err != nil {
+			t.Errorf("Could not verify decoded proof: %v", err)
+		}
+	})
+}
@@ -0,0 +1,212 @@
+package dkg
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// GroupSecretKeyShare combines the secret shares this node received from the other members of the
+// qualified set with its own share of its secret into this node's share of the group secret key.
+func (n *Node) GroupSecretKeyShare() (kyber.Scalar, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	participants, err := n.qualifiedParticipants()
+	if err != nil {
+		return nil, err
+	}
+
+	share := n.secretPoly1.evaluate(n.id)
+	for _, p := range participants {
+		if p.secretShare1 == nil {
+			return nil, MissingSecretShareError{n.id, p.id}
+		}
+		share = n.curve.Scalar().Add(share, p.secretShare1)
+	}
+	return share, nil
+}
+
+// PublicCoefficients retrieves the Feldman commitments to this node's first secret polynomial, which are
+// the vectors related to each of its coefficients. The first of these is this node's PublicKeyPart.
+func (n *Node) PublicCoefficients() PointTuple {
+	coefficients := make(PointTuple, len(n.secretPoly1))
+	for i, c := range n.secretPoly1 {
+		coefficients[i] = n.ScalarBaseMult(c)
+	}
+	return coefficients
+}
+
+// Evaluates a polynomial whose coefficients are the vectors of a PointTuple with argument x
+func (pt PointTuple) evaluate(x kyber.Scalar) kyber.Point {
+	res := pt[0].Clone().Null()
+	xpow := x.Clone().One()
+	for _, coeff := range pt {
+		term := coeff.Clone().Mul(xpow, coeff)
+		res.Add(res, term)
+		xpow.Mul(xpow, x)
+	}
+
+	return res
+}
+
+// validate checks that none of the vectors of a PointTuple is missing
+func (pt PointTuple) validate(group kyber.Group) error {
+	for _, point := range pt {
+		if point == nil {
+			return InvalidCurvePointError{group, point}
+		}
+	}
+	return nil
+}
+
+// verifyPublicCoefficients checks that a first secret share evaluated at x is consistent with a dealer's
+// public coefficients.
+func (n *Node) verifyPublicCoefficients(x, share1 kyber.Scalar, coefficients PointTuple) bool {
+	if share1 == nil || len(coefficients) != len(n.secretPoly1) {
+		return false
+	}
+	return n.ScalarBaseMult(share1).Equal(coefficients.evaluate(x))
+}
+
+// ProcessPublicCoefficients records the public coefficients another node has published and verifies that
+// the first secret share this node received from it, along with every reconstruction share collected for
+// it, matches them. Dealers whose public coefficients don't match have committed to a different first secret
+// polynomial in their verification points, or to a different constant term than the public key part they
+// proved knowledge of. A dealer may only publish its public coefficients once, and once they have failed
+// verification its contribution has to be reconstructed. Valid public coefficients arriving after key
+// extraction timed out still spare the dealer from reconstruction.
+func (n *Node) ProcessPublicCoefficients(id kyber.Scalar, coefficients PointTuple) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	p, err := n.getParticipantByID(id)
+	if p == nil || err != nil {
+		return false, err
+	}
+	if p.publicCoefficients != nil {
+		return false, DuplicatePublicCoefficientsError{n.id, id}
+	}
+	if err := coefficients.validate(n.curve); err != nil {
+		return false, err
+	}
+
+	p.publicCoefficients = coefficients
+	valid := n.verifyPublicCoefficients(n.id, p.secretShare1, coefficients) &&
+		(p.publicKeyPart == nil || coefficients[0].Equal(p.publicKeyPart))
+	for _, rs := range p.reconstructionShares {
+		valid = valid && n.verifyPublicCoefficients(rs.HolderID, rs.SecretShare1, coefficients)
+	}
+	if !valid {
+		p.invalidPublicCoefficients = true
+	}
+	p.missingPublicCoefficients = p.missingPublicCoefficients && p.publicKeyPart == nil
+	return !p.invalidPublicCoefficients, nil
+}
+
+// ComputeGroupPublicKey computes the group public key from the public coefficients published by every
+// member of the qualified set. It only relies on public data, so it may be used by outside observers.
+func ComputeGroupPublicKey(commitments []PointTuple, group kyber.Group) (kyber.Point, error) {
+	if len(commitments) < 1 {
+		return nil, InvalidPointsLengthError{len(commitments)}
+	}
+
+	key := group.Point().Null()
+	for _, coefficients := range commitments {
+		if len(coefficients) < 1 {
+			return nil, InvalidPointsLengthError{len(coefficients)}
+		}
+		if err := coefficients.validate(group); err != nil {
+			return nil, err
+		}
+		key = group.Point().Add(key, coefficients[0])
+	}
+	return key, nil
+}
+
+// ComputePublicKeyShare computes the vector related to the group secret key share of the node with the
+// given ID from the public coefficients published by every member of the qualified set. It only relies
+// on public data, so it may be used by outside observers.
+func ComputePublicKeyShare(commitments []PointTuple, id kyber.Scalar, group kyber.Group) (kyber.Point, error) {
+	if len(commitments) < 1 {
+		return nil, InvalidPointsLengthError{len(commitments)}
+	}
+
+	share := group.Point().Null()
+	for _, coefficients := range commitments {
+		if len(coefficients) < 1 {
+			return nil, InvalidPointsLengthError{len(coefficients)}
+		}
+		if err := coefficients.validate(group); err != nil {
+			return nil, err
+		}
+		share = group.Point().Add(share, coefficients.evaluate(id))
+	}
+	return share, nil
+}
+
+// qualifiedCommitments gathers the public coefficients of every member of the qualified set, starting
+// with this node's own, along with the members whose contribution had to be reconstructed instead.
+func (n *Node) qualifiedCommitments() ([]PointTuple, []*Participant, error) {
+	participants, err := n.qualifiedParticipants()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commitments := []PointTuple{n.PublicCoefficients()}
+	var reconstructed []*Participant
+	for _, p := range participants {
+		if p.reconstructedSecret != nil {
+			reconstructed = append(reconstructed, p)
+			continue
+		}
+		if p.publicCoefficients == nil || p.publicKeyPart == nil || p.invalidPublicCoefficients {
+			return nil, nil, MissingPublicCoefficientsError{n.id, p.id}
+		}
+		commitments = append(commitments, p.publicCoefficients)
+	}
+	return commitments, reconstructed, nil
+}
+
+// GroupPublicKey computes the group public key, which is the sum of the public key parts of every member
+// of the qualified set.
+func (n *Node) GroupPublicKey() (kyber.Point, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	commitments, reconstructed, err := n.qualifiedCommitments()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ComputeGroupPublicKey(commitments, n.curve)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range reconstructed {
+		key = n.curve.Point().Add(key, n.ScalarBaseMult(p.reconstructedSecret))
+	}
+	return key, nil
+}
+
+// PublicKeyShare computes the vector related to the group secret key share of the node with the given ID.
+func (n *Node) PublicKeyShare(id kyber.Scalar) (kyber.Point, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	commitments, reconstructed, err := n.qualifiedCommitments()
+	if err != nil {
+		return nil, err
+	}
+
+	share, err := ComputePublicKeyShare(commitments, id, n.curve)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range reconstructed {
+		part, err := n.reconstructedPublicKeyShare(p, id)
+		if err != nil {
+			return nil, err
+		}
+		share = n.curve.Point().Add(share, part)
+	}
+	return share, nil
+}
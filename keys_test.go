@@ -0,0 +1,266 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+// generateGroupForTesting generates nodes with IDs 1..count and delivers every node's shares,
+// verification points and public key part to every other node.
+func generateGroupForTesting(t *testing.T, count, threshold int) []*Node {
+	curve, g2, zkParam, timeout, _, _, _ := getValidNodeParamsForTesting(t)
+
+	nodes := make([]*Node, count)
+	for i := range nodes {
+		n, err := GenerateNode(
+			curve, g2, zkParam, timeout,
+			curve.Scalar().SetInt64(int64(i+1)), bn256.NewSuite().RandomStream(), threshold,
+		)
+		if n == nil || err != nil {
+			t.Fatalf("Could not generate node: %v", err)
+		}
+		nodes[i] = n
+	}
+
+	for _, receiver := range nodes {
+		for _, dealer := range nodes {
+			if dealer == receiver {
+				continue
+			}
+			share1, share2 := dealer.EvaluatePolynomials(receiver.id)
+			if err := receiver.AddVerificationPoints(dealer.id, dealer.VerificationPoints()); err != nil {
+				t.Fatalf("Could not add verification points: %v", err)
+			}
+			if err := receiver.ReceiveSecretShares(dealer.id, share1, share2); err != nil {
+				t.Fatalf("Could not receive secret shares: %v", err)
+			}
+			ok, err := receiver.ProcessPublicKeyPart(dealer.id, nil, dealer.PublicKeyPart(), dealer.PublicKeyPartProof(nil))
+			if !ok || err != nil {
+				t.Fatalf("Could not process public key part: %v", err)
+			}
+		}
+	}
+	return nodes
+}
+
+func TestGroupSecretKeyShare(t *testing.T) {
+	count, threshold := 5, 3
+	nodes := generateGroupForTesting(t, count, threshold)
+	curve := nodes[0].curve
+
+	if _, err := nodes[0].GroupSecretKeyShare(); reflect.TypeOf(err) != reflect.TypeOf(QUALNotComputedError{}) {
+		t.Errorf("Got unexpected error for key share before QUAL: %v", err)
+	}
+
+	// the last dealer is disqualified by everyone
+	bad := nodes[count-1]
+	for _, n := range nodes[:count-1] {
+		n.ReceiveComplaint(Complaint{nodes[0].id, bad.id, nil, nil})
+		if qual := n.ComputeQUAL(); len(qual) != count-1 {
+			t.Fatalf("Got unexpected QUAL %v", qual)
+		}
+	}
+
+	points := make([]struct{ x, fX kyber.Scalar }, threshold)
+	for i := range points {
+		share, err := nodes[i].GroupSecretKeyShare()
+		if share == nil || err != nil {
+			t.Fatalf("Could not compute group secret key share: %v", err)
+		}
+		points[i].x = nodes[i].id
+		points[i].fX = share
+	}
+
+	secret, err := LagrangeInterpolateZero(points, curve)
+	if err != nil {
+		t.Fatalf("Could not interpolate group secret key: %v", err)
+	}
+
+	expected := curve.Scalar().Zero()
+	for _, n := range nodes[:count-1] {
+		expected = curve.Scalar().Add(expected, n.secretPoly1[0])
+	}
+	if !expected.Equal(secret) {
+		t.Errorf(
+			"Group secret key shares don't interpolate to the group secret key:\n"+
+				"expected: %v\n"+
+				"actual: %v\n",
+			expected, secret,
+		)
+	}
+
+	t.Run("Missing secret share", func(t *testing.T) {
+		p, _ := nodes[0].getParticipantByID(nodes[1].id)
+		p.secretShare1 = nil
+		share, err := nodes[0].GroupSecretKeyShare()
+		if share != nil || reflect.TypeOf(err) != reflect.TypeOf(MissingSecretShareError{}) {
+			t.Errorf("Got unexpected result for missing secret share: %v, %v", share, err)
+		}
+	})
+}
+
+func TestProcessPublicCoefficients(t *testing.T) {
+	nodes := generateGroupForTesting(t, 5, 3)
+	receiver, dealer, other, truncated, late := nodes[0], nodes[1], nodes[2], nodes[3], nodes[4]
+	curve := receiver.curve
+
+	coefficients := dealer.PublicCoefficients()
+	if !coefficients[0].Equal(dealer.PublicKeyPart()) {
+		t.Errorf("First public coefficient should be the public key part")
+	}
+
+	t.Run("Valid public coefficients", func(t *testing.T) {
+		ok, err := receiver.ProcessPublicCoefficients(dealer.id, coefficients)
+		if !ok || err != nil {
+			t.Errorf("Could not verify valid public coefficients: %v", err)
+		}
+		p, _ := receiver.getParticipantByID(dealer.id)
+		if !comparePointTuples(p.publicCoefficients, coefficients) || p.invalidPublicCoefficients {
+			t.Errorf("Public coefficients were not recorded on participant")
+		}
+	})
+
+	t.Run("Public coefficients of another polynomial", func(t *testing.T) {
+		ok, err := receiver.ProcessPublicCoefficients(other.id, dealer.PublicCoefficients())
+		if ok || err != nil {
+			t.Errorf("Verified public coefficients of another polynomial: %v", err)
+		}
+		p, _ := receiver.getParticipantByID(other.id)
+		if !p.invalidPublicCoefficients {
+			t.Errorf("Invalid public coefficients were not recorded on participant")
+		}
+	})
+
+	t.Run("Republished public coefficients", func(t *testing.T) {
+		ok, err := receiver.ProcessPublicCoefficients(other.id, other.PublicCoefficients())
+		if ok || reflect.TypeOf(err) != reflect.TypeOf(DuplicatePublicCoefficientsError{}) {
+			t.Errorf("Got unexpected result republishing public coefficients: %v, %v", ok, err)
+		}
+		p, _ := receiver.getParticipantByID(other.id)
+		if !p.invalidPublicCoefficients || !comparePointTuples(p.publicCoefficients, dealer.PublicCoefficients()) {
+			t.Errorf("Republished public coefficients replaced the invalid ones")
+		}
+	})
+
+	t.Run("Truncated public coefficients", func(t *testing.T) {
+		ok, _ := receiver.ProcessPublicCoefficients(truncated.id, truncated.PublicCoefficients()[:2])
+		if ok {
+			t.Errorf("Verified truncated public coefficients")
+		}
+	})
+
+	t.Run("Missing public coefficient", func(t *testing.T) {
+		missing := late.PublicCoefficients()
+		missing[1] = nil
+		ok, err := receiver.ProcessPublicCoefficients(late.id, missing)
+		if ok || reflect.TypeOf(err) != reflect.TypeOf(InvalidCurvePointError{}) {
+			t.Errorf("Got unexpected result for public coefficients with a missing point: %v, %v", ok, err)
+		}
+		p, _ := receiver.getParticipantByID(late.id)
+		if p.publicCoefficients != nil {
+			t.Errorf("Public coefficients with a missing point were recorded on participant")
+		}
+	})
+
+	t.Run("Reconstruction share before public coefficients", func(t *testing.T) {
+		receiver.ComputeQUAL()
+		other.ComputeQUAL()
+
+		// the coefficients commit to f(x) + x * (x - receiver.id), which only matches the receiver's share
+		bad := late.PublicCoefficients()
+		bad[1] = curve.Point().Sub(bad[1], curve.Point().Mul(receiver.id, nil))
+		bad[2] = curve.Point().Add(bad[2], curve.Point().Base())
+
+		rs, err := other.ReconstructionShare(late.id)
+		if rs == nil || err != nil {
+			t.Fatalf("Could not disclose reconstruction share: %v", err)
+		}
+		if ok, err := receiver.ProcessReconstructionShare(*rs); !ok || err != nil {
+			t.Fatalf("Could not process reconstruction share: %v", err)
+		}
+		if ok, err := receiver.ProcessPublicCoefficients(late.id, bad); ok || err != nil {
+			t.Errorf("Verified public coefficients contradicted by a reconstruction share: %v", err)
+		}
+	})
+}
+
+func TestGroupPublicKey(t *testing.T) {
+	count, threshold := 4, 3
+	nodes := generateGroupForTesting(t, count, threshold)
+	curve := nodes[0].curve
+
+	for _, n := range nodes {
+		n.ComputeQUAL()
+	}
+
+	if _, err := nodes[0].GroupPublicKey(); reflect.TypeOf(err) != reflect.TypeOf(MissingPublicCoefficientsError{}) {
+		t.Errorf("Got unexpected error for group public key without public coefficients: %v", err)
+	}
+
+	commitments := make([]PointTuple, count)
+	for i, dealer := range nodes {
+		commitments[i] = dealer.PublicCoefficients()
+		for _, n := range nodes {
+			if n != dealer {
+				n.ProcessPublicCoefficients(dealer.id, commitments[i])
+			}
+		}
+	}
+
+	expected := curve.Point().Null()
+	for _, n := range nodes {
+		expected = curve.Point().Add(expected, n.PublicKeyPart())
+	}
+
+	for _, n := range nodes {
+		key, err := n.GroupPublicKey()
+		if key == nil || err != nil || !key.Equal(expected) {
+			t.Errorf(
+				"Got unexpected group public key for node %v:\n"+
+					"expected: %v\n"+
+					"actual: %v\n"+
+					"err: %v\n",
+				n.id, expected, key, err,
+			)
+		}
+
+		secretShare, _ := n.GroupSecretKeyShare()
+		for _, observer := range nodes {
+			share, err := observer.PublicKeyShare(n.id)
+			if share == nil || err != nil || !share.Equal(n.ScalarBaseMult(secretShare)) {
+				t.Errorf("Node %v computed wrong public key share for node %v: %v", observer.id, n.id, err)
+			}
+		}
+	}
+
+	t.Run("Outside observer", func(t *testing.T) {
+		key, err := ComputeGroupPublicKey(commitments, curve)
+		if key == nil || err != nil || !key.Equal(expected) {
+			t.Errorf("Observer computed wrong group public key %v: %v", key, err)
+		}
+		share, err := ComputePublicKeyShare(commitments, nodes[0].id, curve)
+		expectedShare, _ := nodes[0].PublicKeyShare(nodes[0].id)
+		if share == nil || err != nil || !share.Equal(expectedShare) {
+			t.Errorf("Observer computed wrong public key share %v: %v", share, err)
+		}
+
+		if _, err := ComputeGroupPublicKey(nil, curve); err == nil {
+			t.Errorf("Computed group public key without commitments")
+		}
+		if _, err := ComputePublicKeyShare([]PointTuple{{}}, nodes[0].id, curve); err == nil {
+			t.Errorf("Computed public key share with empty commitments")
+		}
+
+		missing := []PointTuple{commitments[0], {nil, commitments[1][1], commitments[1][2]}}
+		if _, err := ComputeGroupPublicKey(missing, curve); reflect.TypeOf(err) != reflect.TypeOf(InvalidCurvePointError{}) {
+			t.Errorf("Got unexpected error for group public key with a missing point: %v", err)
+		}
+		missing[1] = PointTuple{commitments[1][0], nil, commitments[1][2]}
+		if _, err := ComputePublicKeyShare(missing, nodes[0].id, curve); reflect.TypeOf(err) != reflect.TypeOf(InvalidCurvePointError{}) {
+			t.Errorf("Got unexpected error for public key share with a missing point: %v", err)
+		}
+	})
+}
@@ -1,14 +1,213 @@
 package dkg
 
-// MessageType enum for dkg message (will likely change)
+import (
+	"fmt"
+
+	"github.com/dedis/kyber"
+)
+
+// MessageType identifies the protocol step a dkg message belongs to
 type MessageType int
 
-// MessageType iota for dkg message (will likely change)
+// MessageType values for every step of the protocol
 const (
-	A MessageType = iota
+	// A dealer's secret shares for a single recipient, delivered privately
+	SecretSharesMessageType MessageType = iota
+	// A dealer's verification points, broadcast to the group
+	VerificationPointsMessageType
+	// A complaint against a dealer, broadcast to the group
+	ComplaintMessageType
+	// A dealer's answer to a complaint, broadcast to the group
+	JustificationMessageType
+	// A qualified dealer's Feldman commitments, broadcast to the group
+	PublicCoefficientsMessageType
+	// A disclosure of the secret shares received from a dealer with bad public coefficients, broadcast to the group
+	ReconstructionShareMessageType
+	// Another message signed with the sender's long-term identity key
+	SignedMessageType
+	// A dealer's secret shares for a single recipient, encrypted so they may be broadcast
+	EncryptedSharesMessageType
+	// A complaint about encrypted secret shares revealing the ECDH shared key, broadcast to the group
+	VerifiableComplaintMessageType
+	// A dealer's public key part with a proof of knowledge of its discrete logarithm, broadcast to the group
+	PublicKeyPartMessageType
 )
 
-// Message struct for dkg message (will likely change)
-type Message struct {
-	mType MessageType
+func (t MessageType) String() string {
+	switch t {
+	case SecretSharesMessageType:
+		return "SecretShares"
+	case VerificationPointsMessageType:
+		return "VerificationPoints"
+	case ComplaintMessageType:
+		return "Complaint"
+	case JustificationMessageType:
+		return "Justification"
+	case PublicCoefficientsMessageType:
+		return "PublicCoefficients"
+	case ReconstructionShareMessageType:
+		return "ReconstructionShare"
+	case SignedMessageType:
+		return "Signed"
+	case EncryptedSharesMessageType:
+		return "EncryptedShares"
+	case VerifiableComplaintMessageType:
+		return "VerifiableComplaint"
+	case PublicKeyPartMessageType:
+		return "PublicKeyPart"
+	}
+	return fmt.Sprintf("MessageType(%d)", int(t))
+}
+
+// Message is implemented by every dkg message
+type Message interface {
+	// The protocol step the message belongs to
+	Type() MessageType
+	// The ID of the node which sent the message
+	Sender() kyber.Scalar
+	// The ID of the key generation session the message belongs to
+	Session() []byte
+}
+
+// Header holds the fields common to every dkg message
+type Header struct {
+	// The ID of the node which sent the message
+	SenderID kyber.Scalar
+	// The ID of the key generation session the message belongs to
+	SessionID []byte
+}
+
+// Sender returns the ID of the node which sent the message
+func (h Header) Sender() kyber.Scalar {
+	return h.SenderID
+}
+
+// Session returns the ID of the key generation session the message belongs to
+func (h Header) Session() []byte {
+	return h.SessionID
+}
+
+// SecretSharesMessage delivers the secret shares a dealer evaluated for a single recipient
+type SecretSharesMessage struct {
+	Header
+	// The ID of the node the secret shares are meant for
+	RecipientID kyber.Scalar
+	// The dealer's secret polynomials evaluated at the recipient's ID
+	SecretShare1 kyber.Scalar
+	SecretShare2 kyber.Scalar
+}
+
+// Type returns SecretSharesMessageType
+func (m *SecretSharesMessage) Type() MessageType {
+	return SecretSharesMessageType
+}
+
+// VerificationPointsMessage broadcasts a dealer's verification points
+type VerificationPointsMessage struct {
+	Header
+	VerificationPoints PointTuple
+}
+
+// Type returns VerificationPointsMessageType
+func (m *VerificationPointsMessage) Type() MessageType {
+	return VerificationPointsMessageType
+}
+
+// ComplaintMessage broadcasts a complaint filed by the sender
+type ComplaintMessage struct {
+	Header
+	Complaint Complaint
+}
+
+// Type returns ComplaintMessageType
+func (m *ComplaintMessage) Type() MessageType {
+	return ComplaintMessageType
+}
+
+// JustificationMessage broadcasts the sender's answer to a complaint against it
+type JustificationMessage struct {
+	Header
+	Justification Justification
+}
+
+// Type returns JustificationMessageType
+func (m *JustificationMessage) Type() MessageType {
+	return JustificationMessageType
+}
+
+// PublicCoefficientsMessage broadcasts a qualified dealer's Feldman commitments to its first secret polynomial
+type PublicCoefficientsMessage struct {
+	Header
+	PublicCoefficients PointTuple
+}
+
+// Type returns PublicCoefficientsMessageType
+func (m *PublicCoefficientsMessage) Type() MessageType {
+	return PublicCoefficientsMessageType
+}
+
+// ReconstructionShareMessage broadcasts the secret shares the sender received from a dealer which
+// published bad public coefficients
+type ReconstructionShareMessage struct {
+	Header
+	ReconstructionShare ReconstructionShare
+}
+
+// Type returns ReconstructionShareMessageType
+func (m *ReconstructionShareMessage) Type() MessageType {
+	return ReconstructionShareMessageType
+}
+
+// SignedMessage wraps the binary encoding of another message with a signature by the sender's long-term
+// identity key. Its header repeats the header of the wrapped message.
+type SignedMessage struct {
+	Header
+	// The binary encoding of the wrapped message
+	Payload []byte
+	// A Schnorr signature over the payload
+	Signature []byte
+}
+
+// Type returns SignedMessageType
+func (m *SignedMessage) Type() MessageType {
+	return SignedMessageType
+}
+
+// EncryptedSharesMessage delivers the secret shares a dealer evaluated for a single recipient over a public
+// broadcast channel, encrypted with the ECDH shared key of a fresh ephemeral key and the recipient's identity key
+type EncryptedSharesMessage struct {
+	Header
+	// The ID of the node the secret shares are meant for
+	RecipientID kyber.Scalar
+	// The encrypted secret shares, as produced by EncryptSecretShares
+	Ciphertext []byte
+}
+
+// Type returns EncryptedSharesMessageType
+func (m *EncryptedSharesMessage) Type() MessageType {
+	return EncryptedSharesMessageType
+}
+
+// VerifiableComplaintMessage broadcasts a verifiable complaint filed by the sender
+type VerifiableComplaintMessage struct {
+	Header
+	Complaint VerifiableComplaint
+}
+
+// Type returns VerifiableComplaintMessageType
+func (m *VerifiableComplaintMessage) Type() MessageType {
+	return VerifiableComplaintMessageType
+}
+
+// PublicKeyPartMessage broadcasts a dealer's public key part along with a proof of knowledge of the constant
+// term of its first secret polynomial, bound to the message's session
+type PublicKeyPartMessage struct {
+	Header
+	PublicKeyPart kyber.Point
+	Proof         KeyProof
+}
+
+// Type returns PublicKeyPartMessageType
+func (m *PublicKeyPartMessage) Type() MessageType {
+	return PublicKeyPartMessageType
 }
@@ -0,0 +1,192 @@
+package dkg
+
+import (
+	"fmt"
+	"time"
+)
+
+// Phase identifies the step of the protocol a node is in
+type Phase int
+
+// Phase values in the order a node goes through them
+const (
+	// Dealers send their secret shares and broadcast their verification points
+	ShareDistributionPhase Phase = iota
+	// Nodes broadcast complaints against dealers whose secret shares failed verification
+	ComplaintsPhase
+	// Dealers answer the complaints filed against them
+	JustificationPhase
+	// Nodes determine the qualified set of dealers
+	QUALPhase
+	// Qualified dealers publish their public coefficients and a proof of knowledge of their public key part,
+	// and the contributions of dealers with bad or missing ones are reconstructed
+	KeyExtractionPhase
+	// The protocol has finished
+	DonePhase
+)
+
+func (p Phase) String() string {
+	switch p {
+	case ShareDistributionPhase:
+		return "ShareDistribution"
+	case ComplaintsPhase:
+		return "Complaints"
+	case JustificationPhase:
+		return "Justification"
+	case QUALPhase:
+		return "QUAL"
+	case KeyExtractionPhase:
+		return "KeyExtraction"
+	case DonePhase:
+		return "Done"
+	}
+	return fmt.Sprintf("Phase(%d)", int(p))
+}
+
+// Start begins the protocol for a group of the given number of nodes, including this one, which must match
+// the configured number of nodes if there is one. Every phase lasts at most the node's timeout, so that the
+// protocol makes progress even if some nodes go silent.
+func (n *Node) Start(participantCount int) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.participantCount != 0 {
+		return AlreadyStartedError{n.id}
+	}
+	if participantCount < len(n.secretPoly1) || participantCount < 2 ||
+		(n.groupSize != 0 && participantCount != n.groupSize) {
+		return InvalidParticipantCountError{participantCount, len(n.secretPoly1)}
+	}
+
+	n.participantCount = participantCount
+	n.enterPhase(ShareDistributionPhase, n.clock.Now())
+	return nil
+}
+
+// Phase returns the phase the node is currently in.
+func (n *Node) Phase() Phase {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.phase
+}
+
+// PhaseDeadline returns the time at which the current phase times out. It is the zero time before the
+// protocol has been started and once it is done.
+func (n *Node) PhaseDeadline() time.Time {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.phaseDeadline()
+}
+
+func (n *Node) phaseDeadline() time.Time {
+	if n.participantCount == 0 || n.phase == DonePhase {
+		return time.Time{}
+	}
+	return n.phaseStarted.Add(n.timeout)
+}
+
+// UpdatePhase moves the node through every phase which has either timed out or received all the messages
+// it expects, and returns the phase the node ends up in. Entering the QUAL phase computes the qualified
+// set, after which the node immediately moves on to key extraction. When key extraction first times out,
+// qualified dealers which haven't published their public coefficients and a proven public key part are
+// treated as having published bad ones and the phase is given another timeout to reconstruct them. It should be called whenever a message
+// has been processed and when the current phase's deadline passes.
+func (n *Node) UpdatePhase() Phase {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.participantCount == 0 {
+		return n.phase
+	}
+
+	now := n.clock.Now()
+	for n.phase != DonePhase {
+		if !n.phaseComplete() && now.Before(n.phaseDeadline()) {
+			break
+		}
+		if n.phase == KeyExtractionPhase && !n.phaseComplete() && n.markMissingPublicCoefficients() {
+			// the dealers which stayed silent are reconstructed, which gets another timeout
+			n.phaseStarted = now
+			break
+		}
+		n.enterPhase(n.phase+1, now)
+	}
+	return n.phase
+}
+
+// markMissingPublicCoefficients marks the public coefficients of every qualified dealer which hasn't published
+// them along with a proven public key part as missing, so that its contribution is reconstructed instead
+// unless they arrive late. It returns whether any dealer was newly marked.
+func (n *Node) markMissingPublicCoefficients() bool {
+	participants, _ := n.qualifiedParticipants()
+
+	marked := false
+	for _, p := range participants {
+		if (p.publicCoefficients == nil || p.publicKeyPart == nil) && !p.needsReconstruction() {
+			p.missingPublicCoefficients = true
+			marked = true
+		}
+	}
+	return marked
+}
+
+// enterPhase moves the node into a phase, starting its timeout
+func (n *Node) enterPhase(phase Phase, now time.Time) {
+	n.phase = phase
+	n.phaseStarted = now
+	if phase == QUALPhase {
+		n.computeQUAL()
+	}
+}
+
+// phaseComplete determines whether every message expected during the current phase has arrived
+func (n *Node) phaseComplete() bool {
+	switch n.phase {
+	case ShareDistributionPhase:
+		if len(n.otherParticipants) < n.participantCount-1 {
+			return false
+		}
+		for _, p := range n.otherParticipants {
+			if p.secretShare1 == nil || p.secretShare2 == nil || p.verificationPoints == nil {
+				return false
+			}
+		}
+		return true
+
+	case ComplaintsPhase:
+		// A node with nothing to complain about stays silent, so the phase only ends on timeout
+		return false
+
+	case JustificationPhase:
+		for _, p := range n.otherParticipants {
+			if p.invalidJustification || p.upheldComplaint {
+				continue
+			}
+			for _, c := range p.complaints {
+				if p.justificationFor(c.AccuserID) == nil {
+					return false
+				}
+			}
+		}
+		return true
+
+	case QUALPhase:
+		return n.qualComputed
+
+	case KeyExtractionPhase:
+		participants, err := n.qualifiedParticipants()
+		if err != nil {
+			return false
+		}
+		for _, p := range participants {
+			if p.reconstructedSecret == nil &&
+				(p.publicCoefficients == nil || p.publicKeyPart == nil || p.invalidPublicCoefficients) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
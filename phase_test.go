@@ -0,0 +1,169 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// useFakeClockForTesting gives a node a fake clock and returns it
+func useFakeClockForTesting(n *Node) *FakeClock {
+	clock := NewFakeClock(time.Unix(0, 0))
+	n.SetClock(clock)
+	return clock
+}
+
+func TestStart(t *testing.T) {
+	nodes := generateGroupForTesting(t, 3, 2)
+	n := nodes[0]
+
+	if phase := n.UpdatePhase(); phase != ShareDistributionPhase || !n.PhaseDeadline().IsZero() {
+		t.Errorf("Node which hasn't started moved to phase %v with deadline %v", phase, n.PhaseDeadline())
+	}
+
+	for _, count := range []int{-1, 0, 1} {
+		if err := n.Start(count); reflect.TypeOf(err) != reflect.TypeOf(InvalidParticipantCountError{}) {
+			t.Errorf("Got unexpected error starting with %v participants: %v", count, err)
+		}
+	}
+
+	if err := n.Start(3); err != nil {
+		t.Fatalf("Could not start protocol: %v", err)
+	}
+	if n.PhaseDeadline().IsZero() {
+		t.Errorf("Started node has no phase deadline")
+	}
+	if err := n.Start(3); reflect.TypeOf(err) != reflect.TypeOf(AlreadyStartedError{}) {
+		t.Errorf("Got unexpected error starting twice: %v", err)
+	}
+}
+
+func TestPhaseTransitions(t *testing.T) {
+	nodes := generateGroupForTesting(t, 3, 2)
+	n := nodes[0]
+	clock := useFakeClockForTesting(n)
+	if err := n.Start(len(nodes)); err != nil {
+		t.Fatalf("Could not start protocol: %v", err)
+	}
+
+	// every secret share has already been delivered
+	if phase := n.UpdatePhase(); phase != ComplaintsPhase {
+		t.Fatalf("Expected complaints phase, got %v", phase)
+	}
+	clock.Advance(n.timeout - 1)
+	if phase := n.UpdatePhase(); phase != ComplaintsPhase {
+		t.Fatalf("Complaints phase ended before timing out, got %v", phase)
+	}
+
+	// without complaints the justification and QUAL phases end right away
+	clock.Advance(1)
+	if phase := n.UpdatePhase(); phase != KeyExtractionPhase {
+		t.Fatalf("Expected key extraction phase, got %v", phase)
+	}
+	if qual, err := n.QUAL(); len(qual) != len(nodes) || err != nil {
+		t.Errorf("Got unexpected QUAL %v: %v", qual, err)
+	}
+
+	for _, dealer := range nodes[1:] {
+		if phase := n.UpdatePhase(); phase != KeyExtractionPhase {
+			t.Fatalf("Key extraction ended before every public coefficient arrived, got %v", phase)
+		}
+		n.ProcessPublicCoefficients(dealer.id, dealer.PublicCoefficients())
+	}
+	if phase := n.UpdatePhase(); phase != DonePhase || !n.PhaseDeadline().IsZero() {
+		t.Fatalf("Expected done phase without deadline, got %v", phase)
+	}
+	if _, err := n.GroupPublicKey(); err != nil {
+		t.Errorf("Could not compute group public key once done: %v", err)
+	}
+}
+
+func TestPhaseTimeouts(t *testing.T) {
+	nodes := generateGroupForTesting(t, 3, 2)
+	n, accuser, dealer := nodes[0], nodes[1], nodes[2]
+	clock := useFakeClockForTesting(n)
+
+	// a fourth node never sends its shares
+	if err := n.Start(len(nodes) + 1); err != nil {
+		t.Fatalf("Could not start protocol: %v", err)
+	}
+	clock.Advance(n.timeout - 1)
+	if phase := n.UpdatePhase(); phase != ShareDistributionPhase {
+		t.Fatalf("Share distribution ended before timing out, got %v", phase)
+	}
+	if deadline := n.PhaseDeadline(); !deadline.Equal(clock.Now().Add(1)) {
+		t.Errorf("Got unexpected phase deadline %v at %v", deadline, clock.Now())
+	}
+	clock.Advance(1)
+	if phase := n.UpdatePhase(); phase != ComplaintsPhase {
+		t.Fatalf("Expected complaints phase, got %v", phase)
+	}
+
+	// the complaint is left unanswered
+	n.ReceiveComplaint(Complaint{accuser.id, dealer.id, nil, nil})
+	clock.Advance(n.timeout)
+	if phase := n.UpdatePhase(); phase != JustificationPhase {
+		t.Fatalf("Justification phase ended with an unanswered complaint, got %v", phase)
+	}
+
+	clock.Advance(n.timeout)
+	if phase := n.UpdatePhase(); phase != KeyExtractionPhase {
+		t.Fatalf("Expected key extraction phase, got %v", phase)
+	}
+	if qual, _ := n.QUAL(); len(qual) != 2 {
+		t.Errorf("Dealer with unanswered complaint wasn't disqualified, got QUAL %v", qual)
+	}
+
+	// the accuser never publishes its public coefficients, so it has to be reconstructed
+	clock.Advance(n.timeout)
+	if phase := n.UpdatePhase(); phase != KeyExtractionPhase {
+		t.Fatalf("Key extraction ended without reconstructing a silent dealer, got %v", phase)
+	}
+	if shares := n.ReconstructionShares(); len(shares) != 1 || !shares[0].DealerID.Equal(accuser.id) {
+		t.Errorf("Silent dealer wasn't marked for reconstruction, got reconstruction shares %v", shares)
+	}
+
+	clock.Advance(n.timeout)
+	if phase := n.UpdatePhase(); phase != DonePhase {
+		t.Fatalf("Key extraction didn't end on timeout, got %v", phase)
+	}
+}
+
+func TestLatePublicCoefficients(t *testing.T) {
+	nodes := generateGroupForTesting(t, 3, 2)
+	n, dealer, late := nodes[0], nodes[1], nodes[2]
+	clock := useFakeClockForTesting(n)
+	if err := n.Start(len(nodes)); err != nil {
+		t.Fatalf("Could not start protocol: %v", err)
+	}
+	if phase := n.UpdatePhase(); phase != ComplaintsPhase {
+		t.Fatalf("Expected complaints phase, got %v", phase)
+	}
+	clock.Advance(n.timeout)
+	if phase := n.UpdatePhase(); phase != KeyExtractionPhase {
+		t.Fatalf("Expected key extraction phase, got %v", phase)
+	}
+	n.ProcessPublicCoefficients(dealer.id, dealer.PublicCoefficients())
+
+	clock.Advance(n.timeout)
+	if phase := n.UpdatePhase(); phase != KeyExtractionPhase {
+		t.Fatalf("Key extraction ended without reconstructing a silent dealer, got %v", phase)
+	}
+	if shares := n.ReconstructionShares(); len(shares) != 1 || !shares[0].DealerID.Equal(late.id) {
+		t.Errorf("Silent dealer wasn't marked for reconstruction, got reconstruction shares %v", shares)
+	}
+
+	// valid public coefficients arriving late spare the dealer from reconstruction
+	if ok, err := n.ProcessPublicCoefficients(late.id, late.PublicCoefficients()); !ok || err != nil {
+		t.Fatalf("Could not verify late public coefficients: %v", err)
+	}
+	if shares := n.ReconstructionShares(); len(shares) != 0 {
+		t.Errorf("Dealer with late public coefficients is still marked for reconstruction: %v", shares)
+	}
+	if phase := n.UpdatePhase(); phase != DonePhase {
+		t.Fatalf("Expected done phase once the late public coefficients arrived, got %v", phase)
+	}
+	if _, err := n.GroupPublicKey(); err != nil {
+		t.Errorf("Could not compute group public key once done: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package dkg
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// maxComplaints returns the number of complaints a dealer may receive before it is disqualified
+// outright, which is the degree of the secret polynomials.
+func (n *Node) maxComplaints() int {
+	return len(n.secretPoly1) - 1
+}
+
+// shouldBeDisqualified determines whether a participant has misbehaved while dealing, given the
+// complaints and justifications seen for it.
+func (p *Participant) shouldBeDisqualified(threshold int, maxComplaints int) bool {
+	if len(p.verificationPoints) != threshold {
+		return true
+	}
+	if p.invalidJustification || p.upheldComplaint || len(p.complaints) > maxComplaints {
+		return true
+	}
+	for _, c := range p.complaints {
+		if p.justificationFor(c.AccuserID) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeQUAL determines the qualified set of dealers from the complaints and justifications this node
+// has seen. Participants which published an invalid justification, left a complaint unanswered, had a
+// verifiable complaint upheld, received more complaints than the degree of the secret polynomials or dealt
+// with the wrong threshold are marked as disqualified.
+// The returned IDs always start with this node's own ID.
+func (n *Node) ComputeQUAL() []kyber.Scalar {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.computeQUAL()
+}
+
+func (n *Node) computeQUAL() []kyber.Scalar {
+	for _, p := range n.otherParticipants {
+		p.disqualified = p.shouldBeDisqualified(len(n.secretPoly1), n.maxComplaints())
+	}
+	n.qualComputed = true
+
+	qual, _ := n.qual()
+	return qual
+}
+
+// QUAL returns the IDs of the qualified set of dealers, as determined by the last call to ComputeQUAL.
+func (n *Node) QUAL() ([]kyber.Scalar, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.qual()
+}
+
+func (n *Node) qual() ([]kyber.Scalar, error) {
+	participants, err := n.qualifiedParticipants()
+	if err != nil {
+		return nil, err
+	}
+
+	qual := make([]kyber.Scalar, 0, len(participants)+1)
+	qual = append(qual, n.id)
+	for _, p := range participants {
+		qual = append(qual, p.id)
+	}
+	return qual, nil
+}
+
+// qualifiedParticipants returns this node's view of the other members of the qualified set.
+func (n *Node) qualifiedParticipants() ([]*Participant, error) {
+	if !n.qualComputed {
+		return nil, QUALNotComputedError{n.id}
+	}
+
+	var participants []*Participant
+	for _, p := range n.otherParticipants {
+		if !p.disqualified {
+			participants = append(participants, p)
+		}
+	}
+	return participants, nil
+}
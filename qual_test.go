@@ -0,0 +1,95 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+func TestComputeQUAL(t *testing.T) {
+	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+	threshold := len(secretPoly1)
+
+	observer, err := NewNode(
+		curve, g2, zkParam, timeout,
+		id, secretPoly1, secretPoly2,
+	)
+	if observer == nil || err != nil {
+		t.Fatalf("Could not create new node: %v", err)
+	}
+
+	if _, err := observer.QUAL(); reflect.TypeOf(err) != reflect.TypeOf(QUALNotComputedError{}) {
+		t.Errorf("Got unexpected error for QUAL before computation: %v", err)
+	}
+
+	dealers := make(map[string]*Node)
+	for _, name := range []string{"honest", "justified", "unjustified", "invalid", "overwhelmed", "mismatched"} {
+		dealerID := curve.Scalar().SetInt64(int64(len(dealers) + 2))
+		dealer, err := GenerateNode(
+			curve, g2, zkParam, timeout,
+			dealerID, bn256.NewSuite().RandomStream(), threshold,
+		)
+		if dealer == nil || err != nil {
+			t.Fatalf("Could not generate dealer node: %v", err)
+		}
+		dealers[name] = dealer
+
+		share1, share2 := dealer.EvaluatePolynomials(id)
+		vpts := dealer.VerificationPoints()
+		if name == "mismatched" {
+			vpts = vpts[:threshold-1]
+		}
+		addParticipantToNodeList(observer, dealerID, share1, share2, vpts)
+	}
+
+	accuserIDs := make([]kyber.Scalar, threshold)
+	for i := range accuserIDs {
+		accuserIDs[i] = curve.Scalar().SetInt64(int64(100 + i))
+	}
+	complain := func(dealer *Node, accuserID kyber.Scalar) {
+		c := Complaint{accuserID, dealer.id, curve.Scalar().One(), curve.Scalar().One()}
+		if err := observer.ReceiveComplaint(c); err != nil {
+			t.Fatalf("Could not receive complaint %v: %v", c, err)
+		}
+	}
+
+	complain(dealers["justified"], accuserIDs[0])
+	j, _ := dealers["justified"].Justify(Complaint{accuserIDs[0], dealers["justified"].id, nil, nil})
+	if ok, err := observer.ProcessJustification(*j); !ok || err != nil {
+		t.Fatalf("Could not process valid justification: %v", err)
+	}
+
+	complain(dealers["unjustified"], accuserIDs[0])
+
+	complain(dealers["invalid"], accuserIDs[0])
+	j, _ = dealers["invalid"].Justify(Complaint{accuserIDs[0], dealers["invalid"].id, nil, nil})
+	j.SecretShare1 = curve.Scalar().Add(j.SecretShare1, curve.Scalar().One())
+	observer.ProcessJustification(*j)
+
+	for _, accuserID := range accuserIDs {
+		complain(dealers["overwhelmed"], accuserID)
+		j, _ := dealers["overwhelmed"].Justify(Complaint{accuserID, dealers["overwhelmed"].id, nil, nil})
+		observer.ProcessJustification(*j)
+	}
+
+	qual := observer.ComputeQUAL()
+	expected := []kyber.Scalar{id, dealers["honest"].id, dealers["justified"].id}
+	if len(qual) != len(expected) {
+		t.Fatalf("Got unexpected QUAL %v, expected %v", qual, expected)
+	}
+	for i := range expected {
+		if !qual[i].Equal(expected[i]) {
+			t.Errorf("Got unexpected QUAL %v, expected %v", qual, expected)
+		}
+	}
+
+	for name, dealer := range dealers {
+		p, _ := observer.getParticipantByID(dealer.id)
+		shouldBeQualified := name == "honest" || name == "justified"
+		if p.disqualified == shouldBeQualified {
+			t.Errorf("Participant %v has disqualified = %v", name, p.disqualified)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package dkg
+
+import (
+	"github.com/dedis/kyber"
+)
+
+// ReconstructionShare is a node's disclosure of the secret shares it received from a qualified dealer
+// which published bad public coefficients, so that the dealer's contribution to the group public key may
+// be reconstructed without it.
+type ReconstructionShare struct {
+	// The ID of the dealer being reconstructed
+	DealerID kyber.Scalar
+	// The ID of the node which received the secret shares
+	HolderID kyber.Scalar
+	// The secret shares the dealer sent to the holder
+	SecretShare1 kyber.Scalar
+	SecretShare2 kyber.Scalar
+}
+
+// needsReconstruction determines whether a dealer's contribution has to be reconstructed because its public
+// coefficients failed verification or are missing.
+func (p *Participant) needsReconstruction() bool {
+	return p.invalidPublicCoefficients || p.missingPublicCoefficients
+}
+
+// reconstructionShareBy searches the reconstruction shares collected for a participant for one disclosed
+// by the given holder.
+func (p *Participant) reconstructionShareBy(holderID kyber.Scalar) *ReconstructionShare {
+	for i := range p.reconstructionShares {
+		if p.reconstructionShares[i].HolderID.Equal(holderID) {
+			return &p.reconstructionShares[i]
+		}
+	}
+	return nil
+}
+
+// ReconstructionShare discloses the secret shares this node received from a qualified dealer.
+func (n *Node) ReconstructionShare(dealerID kyber.Scalar) (*ReconstructionShare, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	p, err := n.getQualifiedParticipantByID(dealerID)
+	if p == nil || err != nil {
+		return nil, err
+	}
+	if p.secretShare1 == nil || p.secretShare2 == nil {
+		return nil, MissingSecretShareError{n.id, p.id}
+	}
+	return &ReconstructionShare{p.id, n.id, p.secretShare1, p.secretShare2}, nil
+}
+
+// ReconstructionShares discloses the secret shares this node received from every qualified dealer whose
+// public coefficients failed verification or are missing.
+func (n *Node) ReconstructionShares() []ReconstructionShare {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	participants, _ := n.qualifiedParticipants()
+
+	var shares []ReconstructionShare
+	for _, p := range participants {
+		if p.needsReconstruction() && p.secretShare1 != nil && p.secretShare2 != nil {
+			shares = append(shares, ReconstructionShare{p.id, n.id, p.secretShare1, p.secretShare2})
+		}
+	}
+	return shares
+}
+
+// ProcessReconstructionShare verifies a reconstruction share disclosed by another node against the dealer's
+// verification points and collects it. A valid share which doesn't match the dealer's public coefficients
+// proves that the dealer published bad public coefficients.
+func (n *Node) ProcessReconstructionShare(rs ReconstructionShare) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if rs.DealerID == nil || rs.HolderID == nil || rs.SecretShare1 == nil || rs.SecretShare2 == nil {
+		return false, InvalidReconstructionShareError{rs}
+	}
+
+	p, err := n.getQualifiedParticipantByID(rs.DealerID)
+	if p == nil || err != nil {
+		return false, err
+	}
+
+	if !n.verifySecretShares(rs.HolderID, rs.SecretShare1, rs.SecretShare2, p.verificationPoints) {
+		return false, nil
+	}
+
+	if p.publicCoefficients != nil && !n.verifyPublicCoefficients(rs.HolderID, rs.SecretShare1, p.publicCoefficients) {
+		p.invalidPublicCoefficients = true
+	}
+	if p.reconstructionShareBy(rs.HolderID) == nil {
+		p.reconstructionShares = append(p.reconstructionShares, rs)
+	}
+	return true, nil
+}
+
+// reconstructionPoints gathers the points of a dealer's first secret polynomial known to this node from
+// its own secret share and the collected reconstruction shares, shifted so that interpolating them at
+// zero evaluates the polynomial at x.
+func (n *Node) reconstructionPoints(p *Participant, x kyber.Scalar) ([]struct{ x, fX kyber.Scalar }, error) {
+	var points []struct{ x, fX kyber.Scalar }
+	if p.reconstructionShareBy(n.id) == nil &&
+		n.verifySecretShares(n.id, p.secretShare1, p.secretShare2, p.verificationPoints) {
+		points = append(points, struct{ x, fX kyber.Scalar }{
+			n.curve.Scalar().Sub(n.id, x), p.secretShare1,
+		})
+	}
+	for _, rs := range p.reconstructionShares {
+		points = append(points, struct{ x, fX kyber.Scalar }{
+			n.curve.Scalar().Sub(rs.HolderID, x), rs.SecretShare1,
+		})
+	}
+
+	threshold := len(p.verificationPoints)
+	if len(points) < threshold {
+		return nil, InsufficientReconstructionSharesError{p.id, len(points), threshold}
+	}
+	return points[:threshold], nil
+}
+
+// ReconstructPublicKeyPart reconstructs the constant term of a qualified dealer's first secret polynomial
+// from the reconstruction shares collected for it, returning the dealer's PublicKeyPart. Once reconstructed,
+// the dealer's contribution is used in place of its public coefficients when computing the group public key
+// and public key shares.
+func (n *Node) ReconstructPublicKeyPart(dealerID kyber.Scalar) (kyber.Point, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	p, err := n.getQualifiedParticipantByID(dealerID)
+	if p == nil || err != nil {
+		return nil, err
+	}
+
+	points, err := n.reconstructionPoints(p, n.curve.Scalar().Zero())
+	if err != nil {
+		return nil, err
+	}
+	secret, err := LagrangeInterpolateZero(points, n.curve)
+	if err != nil {
+		return nil, err
+	}
+
+	p.reconstructedSecret = secret
+	return n.ScalarBaseMult(secret), nil
+}
+
+// reconstructedPublicKeyShare computes a reconstructed dealer's contribution to the public key share of the
+// node with the given ID.
+func (n *Node) reconstructedPublicKeyShare(p *Participant, id kyber.Scalar) (kyber.Point, error) {
+	points, err := n.reconstructionPoints(p, id)
+	if err != nil {
+		return nil, err
+	}
+	share, err := LagrangeInterpolateZero(points, n.curve)
+	if err != nil {
+		return nil, err
+	}
+	return n.ScalarBaseMult(share), nil
+}
+
+// Searches a node for its view of a member of the qualified set, given the member's ID.
+func (n *Node) getQualifiedParticipantByID(id kyber.Scalar) (*Participant, error) {
+	if !n.qualComputed {
+		return nil, QUALNotComputedError{n.id}
+	}
+	p, err := n.getParticipantByID(id)
+	if p == nil || err != nil {
+		return nil, err
+	}
+	if p.disqualified {
+		return nil, ParticipantDisqualifiedError{n.id, id}
+	}
+	return p, nil
+}
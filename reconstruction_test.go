@@ -0,0 +1,90 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReconstructPublicKeyPart(t *testing.T) {
+	count, threshold := 4, 3
+	nodes := generateGroupForTesting(t, count, threshold)
+	curve := nodes[0].curve
+	faulty, honest := nodes[count-1], nodes[:count-1]
+
+	for _, n := range nodes {
+		n.ComputeQUAL()
+	}
+
+	// the faulty dealer publishes public coefficients which don't match its verification points
+	badCoefficients := faulty.PublicCoefficients()
+	badCoefficients[1] = curve.Point().Add(badCoefficients[1], curve.Point().Base())
+
+	for _, dealer := range nodes {
+		coefficients := dealer.PublicCoefficients()
+		if dealer == faulty {
+			coefficients = badCoefficients
+		}
+		for _, n := range honest {
+			if n != dealer {
+				n.ProcessPublicCoefficients(dealer.id, coefficients)
+			}
+		}
+	}
+
+	if _, err := honest[0].GroupPublicKey(); reflect.TypeOf(err) != reflect.TypeOf(MissingPublicCoefficientsError{}) {
+		t.Errorf("Got unexpected error for group public key with bad public coefficients: %v", err)
+	}
+	if _, err := honest[0].ReconstructPublicKeyPart(faulty.id); reflect.TypeOf(err) != reflect.TypeOf(InsufficientReconstructionSharesError{}) {
+		t.Errorf("Got unexpected error for reconstruction without shares: %v", err)
+	}
+
+	for _, holder := range honest {
+		shares := holder.ReconstructionShares()
+		if len(shares) != 1 || !shares[0].DealerID.Equal(faulty.id) {
+			t.Fatalf("Got unexpected reconstruction shares %v", shares)
+		}
+		for _, n := range honest {
+			if n == holder {
+				continue
+			}
+			ok, err := n.ProcessReconstructionShare(shares[0])
+			if !ok || err != nil {
+				t.Fatalf("Could not process valid reconstruction share: %v", err)
+			}
+		}
+	}
+
+	t.Run("Forged reconstruction share", func(t *testing.T) {
+		forged, _ := honest[0].ReconstructionShare(faulty.id)
+		forged.SecretShare1 = curve.Scalar().Add(forged.SecretShare1, curve.Scalar().One())
+		ok, err := honest[1].ProcessReconstructionShare(*forged)
+		if ok || err != nil {
+			t.Errorf("Processed forged reconstruction share: %v", err)
+		}
+	})
+
+	expectedKey := curve.Point().Null()
+	for _, n := range nodes {
+		expectedKey = curve.Point().Add(expectedKey, n.PublicKeyPart())
+	}
+
+	for _, n := range honest {
+		part, err := n.ReconstructPublicKeyPart(faulty.id)
+		if part == nil || err != nil || !part.Equal(faulty.PublicKeyPart()) {
+			t.Fatalf("Node %v reconstructed wrong public key part %v: %v", n.id, part, err)
+		}
+
+		key, err := n.GroupPublicKey()
+		if key == nil || err != nil || !key.Equal(expectedKey) {
+			t.Errorf("Node %v computed wrong group public key %v: %v", n.id, key, err)
+		}
+
+		for _, other := range honest {
+			secretShare, _ := other.GroupSecretKeyShare()
+			share, err := n.PublicKeyShare(other.id)
+			if share == nil || err != nil || !share.Equal(other.ScalarBaseMult(secretShare)) {
+				t.Errorf("Node %v computed wrong public key share for %v: %v", n.id, other.id, err)
+			}
+		}
+	}
+}
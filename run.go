@@ -0,0 +1,409 @@
+package dkg
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/dedis/kyber"
+)
+
+// KeyMaterial is the outcome of a completed run of the protocol
+type KeyMaterial struct {
+	// The public key of the whole group
+	GroupPublicKey kyber.Point
+	// This node's share of the group secret key
+	SecretKeyShare kyber.Scalar
+	// The IDs of the qualified set of dealers, starting with this node's own ID
+	QUAL []kyber.Scalar
+}
+
+// runner drives a node through the protocol over a transport
+type runner struct {
+	n         *Node
+	transport Transport
+	sessionID []byte
+	peers     []kyber.Scalar
+	// Whether secret shares are encrypted for the peers' identity keys and broadcast
+	encrypted bool
+
+	// Messages which arrived before the node reached the phase they belong to
+	pending []Message
+	// The dealers this node has already disclosed its reconstruction shares for
+	disclosed map[string]bool
+}
+
+// Run drives the node through every phase of the protocol with the given peers, exchanging messages over
+// the transport, and returns the resulting key material. Every message sent is tagged with the session ID,
+// and received messages from other sessions are dropped, so that several ceremonies may share a transport.
+// Peers which can't be reached are treated as silent, so that the phase timeouts exclude them.
+// Run blocks until the protocol is done or the context is cancelled, in which case the context's error is
+// returned; callers wanting to do other work meanwhile run it in a goroutine of their own.
+//
+// Once the node has an identity key, its secret shares are encrypted for every peer's registered identity key
+// and broadcast, and bad shares are answered with verifiable complaints; a peer without a registered identity
+// key is an error. Otherwise the shares are delivered with the transport's Send, which then has to keep them
+// confidential: a MemoryHub does, while a TCPTransport sends them in the clear.
+func (n *Node) Run(ctx context.Context, transport Transport, sessionID []byte, peers []kyber.Scalar) (*KeyMaterial, error) {
+	encrypted, err := n.encryptsSecretShares(peers)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.Start(len(peers) + 1); err != nil {
+		return nil, err
+	}
+
+	r := &runner{
+		n:         n,
+		transport: transport,
+		sessionID: sessionID,
+		peers:     peers,
+		encrypted: encrypted,
+		disclosed: make(map[string]bool),
+	}
+	return r.run(ctx)
+}
+
+// encryptsSecretShares determines whether this node encrypts its secret shares for the given peers, which
+// it does once it has an identity key. Every peer's identity key must then be registered.
+func (n *Node) encryptsSecretShares(peers []kyber.Scalar) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.identityKey == nil {
+		return false, nil
+	}
+	for _, peer := range peers {
+		if _, ok := n.identityKeys[scalarKey(peer)]; !ok {
+			return false, MissingIdentityKeyError{peer}
+		}
+	}
+	return true, nil
+}
+
+// run deals this node's secret, then handles incoming messages and phase timeouts until the protocol is done
+func (r *runner) run(ctx context.Context) (*KeyMaterial, error) {
+	n := r.n
+	if err := r.deal(); err != nil {
+		return nil, err
+	}
+
+	phase, deadline := n.Phase(), n.PhaseDeadline()
+	timeout := r.timer()
+	for {
+		if next := n.UpdatePhase(); next != phase {
+			// every phase skipped over has its entry actions run in order
+			for phase < next {
+				phase++
+				if err := r.enter(phase); err != nil {
+					return nil, err
+				}
+			}
+			if phase == DonePhase {
+				return r.keyMaterial()
+			}
+			deadline, timeout = n.PhaseDeadline(), r.timer()
+			continue
+		}
+		if next := n.PhaseDeadline(); !next.Equal(deadline) {
+			// key extraction was extended to reconstruct the dealers which published no public coefficients
+			if err := r.reconstructInvalid(); err != nil {
+				return nil, err
+			}
+			deadline, timeout = next, r.timer()
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+		case m, ok := <-r.transport.Receive():
+			if !ok {
+				return nil, TransportClosedError{n.id}
+			}
+			if err := r.handle(m); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// timer returns a channel which receives once the node's current phase times out
+func (r *runner) timer() <-chan time.Time {
+	r.n.mu.Lock()
+	defer r.n.mu.Unlock()
+	return r.n.clock.After(r.n.phaseDeadline().Sub(r.n.clock.Now()))
+}
+
+// delivered filters the error of sending a message down to the ones which stop the run. A peer which can't
+// be reached is treated just like one which went silent, so only the transport being closed is fatal.
+func delivered(err error) error {
+	if _, closed := err.(TransportClosedError); closed {
+		return err
+	}
+	return nil
+}
+
+// header returns the header of messages sent by this node
+func (r *runner) header() Header {
+	return Header{r.n.id, r.sessionID}
+}
+
+// deal sends this node's secret shares to every peer, or broadcasts them encrypted for each peer, then
+// broadcasts its verification points
+func (r *runner) deal() error {
+	n := r.n
+	for _, peer := range r.peers {
+		if r.encrypted {
+			ciphertext, err := n.EncryptSecretShares(peer, r.sessionID)
+			if err != nil {
+				return err
+			}
+			if err := delivered(r.transport.Broadcast(&EncryptedSharesMessage{r.header(), peer, ciphertext})); err != nil {
+				return err
+			}
+			continue
+		}
+
+		share1, share2 := n.EvaluatePolynomials(peer)
+		if err := delivered(r.transport.Send(peer, &SecretSharesMessage{r.header(), peer, share1, share2})); err != nil {
+			return err
+		}
+	}
+	return delivered(r.transport.Broadcast(&VerificationPointsMessage{r.header(), n.VerificationPoints()}))
+}
+
+// enter performs the actions of a phase the node has just moved into, then handles any messages which
+// were waiting for it
+func (r *runner) enter(phase Phase) error {
+	n := r.n
+	switch phase {
+	case ComplaintsPhase:
+		var complaints []Complaint
+		n.mu.Lock()
+		for _, p := range n.otherParticipants {
+			if ok, _ := n.processSecretShareVerification(p.id); ok {
+				continue
+			}
+			if c := p.complaintBy(n.id); c != nil {
+				complaints = append(complaints, *c)
+			}
+		}
+		n.mu.Unlock()
+
+		for _, c := range complaints {
+			if err := delivered(r.transport.Broadcast(r.complaintMessage(c))); err != nil {
+				return err
+			}
+		}
+
+	case JustificationPhase:
+		for _, j := range n.Justifications() {
+			if err := delivered(r.transport.Broadcast(&JustificationMessage{r.header(), j})); err != nil {
+				return err
+			}
+		}
+
+	case KeyExtractionPhase:
+		// the public key part is only revealed once the qualified set is fixed, so that no dealer can choose
+		// whether to be disqualified after seeing the others' parts
+		proof := n.PublicKeyPartProof(r.sessionID)
+		if err := delivered(r.transport.Broadcast(&PublicKeyPartMessage{r.header(), n.PublicKeyPart(), proof})); err != nil {
+			return err
+		}
+		m := &PublicCoefficientsMessage{r.header(), n.PublicCoefficients()}
+		if err := delivered(r.transport.Broadcast(m)); err != nil {
+			return err
+		}
+	}
+
+	pending := r.pending
+	r.pending = nil
+	for _, m := range pending {
+		if err := r.handle(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// complaintMessage returns the message filing a complaint. Complaints about encrypted secret shares are
+// made verifiable, and upheld by this node right away, while a dealer whose encrypted shares never arrived
+// is accused with a plain complaint, which it has to answer with a justification.
+func (r *runner) complaintMessage(c Complaint) Message {
+	n := r.n
+	if r.encrypted {
+		if vc, err := n.VerifiableComplaint(c.AccusedID); err == nil {
+			n.ProcessVerifiableComplaint(*vc)
+			return &VerifiableComplaintMessage{r.header(), *vc}
+		}
+	}
+	return &ComplaintMessage{r.header(), c}
+}
+
+// messagePhase returns the phase a message type is sent in
+func messagePhase(t MessageType) Phase {
+	switch t {
+	case ComplaintMessageType, VerifiableComplaintMessageType:
+		return ComplaintsPhase
+	case JustificationMessageType:
+		return JustificationPhase
+	case PublicCoefficientsMessageType, PublicKeyPartMessageType, ReconstructionShareMessageType:
+		return KeyExtractionPhase
+	}
+	return ShareDistributionPhase
+}
+
+// peerID returns the ID of the peer which sent a message, or nil if the sender isn't a peer
+func (r *runner) peerID(m Message) kyber.Scalar {
+	if m.Sender() == nil {
+		return nil
+	}
+	for _, peer := range r.peers {
+		if peer.Equal(m.Sender()) {
+			return peer
+		}
+	}
+	return nil
+}
+
+// handle processes a message from a peer. Messages for a later phase are kept until the node reaches
+// it, while messages for an earlier phase arrived too late and are dropped, as are messages from another
+// session and messages which fail verification. Only the transport being closed is returned as an error.
+func (r *runner) handle(m Message) error {
+	n := r.n
+	sender := r.peerID(m)
+	if sender == nil || !bytes.Equal(m.Session(), r.sessionID) {
+		return nil
+	}
+	if phase, current := messagePhase(m.Type()), n.Phase(); phase > current {
+		r.pending = append(r.pending, m)
+		return nil
+	} else if phase < current {
+		return nil
+	}
+
+	switch m := m.(type) {
+	case *SecretSharesMessage:
+		if m.RecipientID == nil || !m.RecipientID.Equal(n.id) {
+			return nil
+		}
+		n.ReceiveSecretShares(sender, m.SecretShare1, m.SecretShare2)
+
+	case *EncryptedSharesMessage:
+		// every ciphertext is kept so that complaints about it can be adjudicated, while shares which don't
+		// decrypt are complained about just like shares which don't verify
+		if n.RecordEncryptedSecretShares(m) != nil || !m.RecipientID.Equal(n.id) {
+			return nil
+		}
+		if share1, share2, err := n.DecryptSecretShares(sender, m.Session(), m.Ciphertext); err == nil {
+			n.ReceiveSecretShares(sender, share1, share2)
+		}
+
+	case *VerificationPointsMessage:
+		n.AddVerificationPoints(sender, m.VerificationPoints)
+
+	case *PublicKeyPartMessage:
+		if ok, err := n.ProcessPublicKeyPart(sender, m.Session(), m.PublicKeyPart, m.Proof); !ok && err == nil {
+			return r.reconstruct(sender)
+		}
+
+	case *ComplaintMessage:
+		if m.Complaint.AccuserID != nil && m.Complaint.AccuserID.Equal(sender) {
+			n.ReceiveComplaint(m.Complaint)
+		}
+
+	case *VerifiableComplaintMessage:
+		if m.Complaint.AccuserID != nil && m.Complaint.AccuserID.Equal(sender) {
+			n.ProcessVerifiableComplaint(m.Complaint)
+		}
+
+	case *JustificationMessage:
+		if m.Justification.DealerID != nil && m.Justification.DealerID.Equal(sender) {
+			n.ProcessJustification(m.Justification)
+		}
+
+	case *PublicCoefficientsMessage:
+		if ok, err := n.ProcessPublicCoefficients(sender, m.PublicCoefficients); !ok && err == nil {
+			return r.reconstruct(sender)
+		}
+
+	case *ReconstructionShareMessage:
+		rs := m.ReconstructionShare
+		if rs.HolderID == nil || !rs.HolderID.Equal(sender) {
+			return nil
+		}
+		if ok, _ := n.ProcessReconstructionShare(rs); ok {
+			return r.reconstruct(rs.DealerID)
+		}
+	}
+	return nil
+}
+
+// reconstruct discloses this node's reconstruction share for a dealer whose public coefficients failed
+// verification or never arrived, then reconstructs the dealer's contribution once enough shares have been collected
+func (r *runner) reconstruct(dealerID kyber.Scalar) error {
+	n := r.n
+	n.mu.Lock()
+	p, _ := n.getQualifiedParticipantByID(dealerID)
+	invalid := p != nil && p.needsReconstruction()
+	reconstructed := invalid && p.reconstructedSecret != nil
+	n.mu.Unlock()
+	if !invalid {
+		return nil
+	}
+
+	if key := scalarKey(dealerID); !r.disclosed[key] {
+		r.disclosed[key] = true
+		if rs, err := n.ReconstructionShare(dealerID); err == nil {
+			if err := delivered(r.transport.Broadcast(&ReconstructionShareMessage{r.header(), *rs})); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !reconstructed {
+		n.ReconstructPublicKeyPart(dealerID)
+	}
+	return nil
+}
+
+// reconstructInvalid starts reconstructing every qualified dealer whose public coefficients are bad or missing
+func (r *runner) reconstructInvalid() error {
+	n := r.n
+	n.mu.Lock()
+	participants, _ := n.qualifiedParticipants()
+	var invalid []kyber.Scalar
+	for _, p := range participants {
+		if p.needsReconstruction() {
+			invalid = append(invalid, p.id)
+		}
+	}
+	n.mu.Unlock()
+
+	for _, id := range invalid {
+		if err := r.reconstruct(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyMaterial collects the outcome of the protocol once it is done
+func (r *runner) keyMaterial() (*KeyMaterial, error) {
+	n := r.n
+	groupKey, err := n.GroupPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	share, err := n.GroupSecretKeyShare()
+	if err != nil {
+		return nil, err
+	}
+	qual, err := n.QUAL()
+	if err != nil {
+		return nil, err
+	}
+	return &KeyMaterial{groupKey, share, qual}, nil
+}
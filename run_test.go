@@ -0,0 +1,492 @@
+package dkg
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dedis/kyber"
+)
+
+// countingTransport forwards the messages another transport receives, signalling every message the node
+// has taken delivery of. Whenever the node waits for a message in a phase it hasn't waited in before, the
+// phase is reported, so that tests can tell when to advance a fake clock. Messages sent are passed through
+// tamper, and never sent if it returns nil.
+type countingTransport struct {
+	Transport
+	out       chan Message
+	delivered chan struct{}
+	stop      chan struct{}
+	tamper    func(Message) Message
+
+	node   *Node
+	phases chan<- Phase
+	last   Phase
+}
+
+func newCountingTransport(inner Transport, n *Node, phases chan<- Phase) *countingTransport {
+	t := &countingTransport{
+		Transport: inner,
+		out:       make(chan Message),
+		delivered: make(chan struct{}, 1024),
+		stop:      make(chan struct{}),
+		node:      n,
+		phases:    phases,
+		last:      -1,
+	}
+	go func() {
+		defer close(t.out)
+		for m := range inner.Receive() {
+			select {
+			case t.out <- m:
+				t.delivered <- struct{}{}
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+	return t
+}
+
+func (t *countingTransport) Send(to kyber.Scalar, m Message) error {
+	if t.tamper != nil {
+		if m = t.tamper(m); m == nil {
+			return nil
+		}
+	}
+	return t.Transport.Send(to, m)
+}
+
+func (t *countingTransport) Broadcast(m Message) error {
+	if t.tamper != nil {
+		if m = t.tamper(m); m == nil {
+			return nil
+		}
+	}
+	return t.Transport.Broadcast(m)
+}
+
+// dropPublicCoefficientsForTesting is a tamper function which keeps a node from publishing its public coefficients
+func dropPublicCoefficientsForTesting(m Message) Message {
+	if m.Type() == PublicCoefficientsMessageType {
+		return nil
+	}
+	return m
+}
+
+// Receive is only called by the node's runner, each time it waits for a message
+func (t *countingTransport) Receive() <-chan Message {
+	if phase := t.node.Phase(); phase != t.last {
+		t.last = phase
+		t.phases <- phase
+	}
+	return t.out
+}
+
+func (t *countingTransport) Close() error {
+	close(t.stop)
+	return t.Transport.Close()
+}
+
+// runResult is the outcome of running the protocol on one node
+type runResult struct {
+	id  kyber.Scalar
+	km  *KeyMaterial
+	err error
+}
+
+// protocolRunForTesting runs the protocol on a group of nodes sharing a fake clock, reporting every phase a
+// node enters
+type protocolRunForTesting struct {
+	nodes      []*Node
+	transports []*countingTransport
+	clock      *FakeClock
+	phases     chan Phase
+	results    chan runResult
+}
+
+// startRunForTesting starts the protocol on the first running nodes of a group of count nodes, while the
+// remaining nodes stay silent.
+func startRunForTesting(ctx context.Context, t *testing.T, count, running, threshold int) *protocolRunForTesting {
+	run := newRunForTesting(t, count, threshold)
+	run.start(ctx, running)
+	return run
+}
+
+// newRunForTesting connects a group of count nodes to a hub without starting the protocol
+func newRunForTesting(t *testing.T, count, threshold int) *protocolRunForTesting {
+	nodes := generateGroupForTesting(t, count, threshold)
+	run := &protocolRunForTesting{
+		nodes:   nodes,
+		clock:   NewFakeClock(time.Unix(0, 0)),
+		phases:  make(chan Phase, 64*count),
+		results: make(chan runResult, count),
+	}
+
+	hub := NewMemoryHub()
+	for _, n := range nodes {
+		// the nodes start from scratch rather than from the shares the group helper exchanged
+		n.otherParticipants, n.participants = nil, nil
+		n.SetClock(run.clock)
+
+		inner, err := hub.Transport(n.id)
+		if err != nil {
+			t.Fatalf("Could not connect to hub: %v", err)
+		}
+		transport := newCountingTransport(inner, n, run.phases)
+		run.transports = append(run.transports, transport)
+	}
+	return run
+}
+
+// start runs the protocol on the first running nodes of the group
+func (run *protocolRunForTesting) start(ctx context.Context, running int) {
+	ids := make([]kyber.Scalar, len(run.nodes))
+	for i, n := range run.nodes {
+		ids[i] = n.id
+	}
+
+	for i, n := range run.nodes[:running] {
+		peers := append(append([]kyber.Scalar{}, ids[:i]...), ids[i+1:]...)
+		go func(n *Node, transport Transport) {
+			km, err := n.Run(ctx, transport, []byte("session"), peers)
+			run.results <- runResult{n.id, km, err}
+		}(n, run.transports[i])
+	}
+}
+
+// close disconnects every node from the hub
+func (run *protocolRunForTesting) close() {
+	for _, transport := range run.transports {
+		transport.Close()
+	}
+}
+
+// waitForPhase waits until the given number of nodes have entered a phase
+func (run *protocolRunForTesting) waitForPhase(t *testing.T, phase Phase, count int) {
+	for count > 0 {
+		select {
+		case p := <-run.phases:
+			if p == phase {
+				count--
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Nodes didn't enter phase %v", phase)
+		}
+	}
+}
+
+// waitForDeliveries waits until a node has taken delivery of the given number of messages
+func (run *protocolRunForTesting) waitForDeliveries(t *testing.T, i, count int) {
+	for ; count > 0; count-- {
+		select {
+		case <-run.transports[i].delivered:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Node %v didn't receive its messages", run.nodes[i].id)
+		}
+	}
+}
+
+// waitForResults collects the outcome of the given number of runs
+func (run *protocolRunForTesting) waitForResults(t *testing.T, count int) []runResult {
+	results := make([]runResult, count)
+	for i := range results {
+		select {
+		case results[i] = <-run.results:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Protocol didn't finish")
+		}
+	}
+	return results
+}
+
+// checkKeyMaterialForTesting checks that every run agreed on the QUAL and group public key, and that the
+// secret key shares interpolate to the group secret key
+func checkKeyMaterialForTesting(t *testing.T, group kyber.Group, results []runResult, qualSize, threshold int) {
+	first := results[0].km
+	for _, res := range results {
+		if res.km == nil || res.err != nil {
+			t.Fatalf("Could not run protocol on node %v: %v", res.id, res.err)
+		}
+		if len(res.km.QUAL) != qualSize || !res.km.GroupPublicKey.Equal(first.GroupPublicKey) {
+			t.Errorf(
+				"Node %v disagrees on the outcome:\n"+
+					"QUAL: %v\n"+
+					"group public key: %v\n"+
+					"expected group public key: %v\n",
+				res.id, res.km.QUAL, res.km.GroupPublicKey, first.GroupPublicKey,
+			)
+		}
+	}
+
+	points := make([]struct{ x, fX kyber.Scalar }, threshold)
+	for i := range points {
+		points[i].x = results[i].id
+		points[i].fX = results[i].km.SecretKeyShare
+	}
+	secret, err := LagrangeInterpolateZero(points, group)
+	if err != nil || !group.Point().Mul(secret, nil).Equal(first.GroupPublicKey) {
+		t.Errorf("Secret key shares don't match the group public key: %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	count, threshold := 4, 3
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	run := startRunForTesting(ctx, t, count, count, threshold)
+	defer run.close()
+
+	// every share arrives, so only the complaints phase has to time out
+	run.waitForPhase(t, ComplaintsPhase, count)
+	run.clock.Advance(run.nodes[0].timeout)
+
+	checkKeyMaterialForTesting(t, run.nodes[0].curve, run.waitForResults(t, count), count, threshold)
+}
+
+func TestRunWithSilentParticipant(t *testing.T) {
+	count, threshold := 4, 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the last node never takes part
+	running := count - 1
+	run := startRunForTesting(ctx, t, count, running, threshold)
+	defer run.close()
+
+	// every running node receives shares and verification points from the others
+	for i := 0; i < running; i++ {
+		run.waitForDeliveries(t, i, 2*(running-1))
+	}
+	run.clock.Advance(run.nodes[0].timeout)
+	run.waitForPhase(t, ComplaintsPhase, running)
+	run.clock.Advance(run.nodes[0].timeout)
+
+	checkKeyMaterialForTesting(t, run.nodes[0].curve, run.waitForResults(t, running), running, threshold)
+}
+
+func TestRunWithUnreachableParticipant(t *testing.T) {
+	count, threshold := 4, 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the last node is disconnected from the hub, so sending to it fails
+	running := count - 1
+	run := newRunForTesting(t, count, threshold)
+	run.transports[running].Close()
+	run.transports = run.transports[:running]
+	run.start(ctx, running)
+	defer run.close()
+
+	for i := 0; i < running; i++ {
+		run.waitForDeliveries(t, i, 2*(running-1))
+	}
+	run.clock.Advance(run.nodes[0].timeout)
+	run.waitForPhase(t, ComplaintsPhase, running)
+	run.clock.Advance(run.nodes[0].timeout)
+
+	checkKeyMaterialForTesting(t, run.nodes[0].curve, run.waitForResults(t, running), running, threshold)
+}
+
+func TestRunWithDealerSilentAfterQUAL(t *testing.T) {
+	count, threshold := 4, 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the last node takes part in dealing but never publishes its public coefficients
+	run := newRunForTesting(t, count, threshold)
+	silent := count - 1
+	run.transports[silent].tamper = dropPublicCoefficientsForTesting
+	run.start(ctx, count)
+	defer run.close()
+
+	run.waitForPhase(t, ComplaintsPhase, count)
+	run.clock.Advance(run.nodes[0].timeout)
+
+	// every node receives shares, verification points and a public key part from each peer, then public
+	// coefficients from the peers which publish them
+	run.waitForPhase(t, KeyExtractionPhase, count)
+	for i := 0; i < silent; i++ {
+		run.waitForDeliveries(t, i, 3*(count-1)+(silent-1))
+	}
+	run.clock.Advance(run.nodes[0].timeout)
+
+	checkKeyMaterialForTesting(t, run.nodes[0].curve, run.waitForResults(t, count), count, threshold)
+}
+
+func TestRunWithLatePublicCoefficients(t *testing.T) {
+	count, threshold := 4, 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the last node's public coefficients only reach the first node after its key extraction timed out
+	run := newRunForTesting(t, count, threshold)
+	late, dealer := 0, count-1
+	run.transports[dealer].tamper = dropPublicCoefficientsForTesting
+	run.start(ctx, count)
+	defer run.close()
+
+	run.waitForPhase(t, ComplaintsPhase, count)
+	run.clock.Advance(run.nodes[0].timeout)
+	run.waitForPhase(t, KeyExtractionPhase, count)
+
+	coefficients := &PublicCoefficientsMessage{
+		Header{run.nodes[dealer].id, []byte("session")},
+		run.nodes[dealer].PublicCoefficients(),
+	}
+	send := func(i int) {
+		if err := run.transports[dealer].Transport.Send(run.nodes[i].id, coefficients); err != nil {
+			t.Fatalf("Could not send public coefficients: %v", err)
+		}
+	}
+	for i := range run.nodes {
+		if i != late && i != dealer {
+			send(i)
+		}
+	}
+	results := run.waitForResults(t, count-1)
+
+	run.waitForDeliveries(t, late, 3*(count-1)+(count-2))
+	deadline := run.nodes[late].PhaseDeadline()
+	run.clock.Advance(run.nodes[0].timeout)
+	for start := time.Now(); run.nodes[late].PhaseDeadline().Equal(deadline); time.Sleep(time.Millisecond) {
+		if time.Since(start) > 10*time.Second {
+			t.Fatalf("Key extraction wasn't extended for the missing public coefficients")
+		}
+	}
+	send(late)
+
+	results = append(results, run.waitForResults(t, 1)...)
+	checkKeyMaterialForTesting(t, run.nodes[0].curve, results, count, threshold)
+}
+
+func TestRunWithEncryptedShares(t *testing.T) {
+	count, threshold := 4, 3
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	run := newRunForTesting(t, count, threshold)
+	setIdentityKeysForTesting(t, run.nodes)
+	var plaintext int32
+	for _, transport := range run.transports {
+		transport.tamper = func(m Message) Message {
+			if m.Type() == SecretSharesMessageType {
+				atomic.AddInt32(&plaintext, 1)
+			}
+			return m
+		}
+	}
+	run.start(ctx, count)
+	defer run.close()
+
+	run.waitForPhase(t, ComplaintsPhase, count)
+	run.clock.Advance(run.nodes[0].timeout)
+
+	checkKeyMaterialForTesting(t, run.nodes[0].curve, run.waitForResults(t, count), count, threshold)
+	if sent := atomic.LoadInt32(&plaintext); sent != 0 {
+		t.Errorf("Sent %v secret shares in the clear despite identity keys", sent)
+	}
+
+	t.Run("Missing identity key", func(t *testing.T) {
+		nodes := generateGroupForTesting(t, 2, 2)
+		n := nodes[0]
+		n.SetIdentityKey(n.curve.Scalar().SetInt64(7))
+		if _, err := n.Run(ctx, nil, []byte("session"), []kyber.Scalar{nodes[1].id}); reflect.TypeOf(err) != reflect.TypeOf(MissingIdentityKeyError{}) {
+			t.Errorf("Got unexpected error running without a peer's identity key: %v", err)
+		}
+		if phase := n.Phase(); phase != ShareDistributionPhase || !n.PhaseDeadline().IsZero() {
+			t.Errorf("Node was started despite a missing identity key")
+		}
+	})
+}
+
+func TestRunWithVerifiableComplaint(t *testing.T) {
+	count, threshold := 4, 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	run := newRunForTesting(t, count, threshold)
+	setIdentityKeysForTesting(t, run.nodes)
+	curve := run.nodes[0].curve
+
+	// the last node encrypts shares for the first node which don't match its verification points
+	accuser, dealer := 0, count-1
+	run.transports[dealer].tamper = func(m Message) Message {
+		em, ok := m.(*EncryptedSharesMessage)
+		if !ok || !em.RecipientID.Equal(run.nodes[accuser].id) {
+			return m
+		}
+		one := curve.Scalar().One()
+		recipientKey := run.nodes[accuser].IdentityPublicKey()
+		ciphertext, err := encryptSecretShares(curve, recipientKey, em.SessionID, em.SenderID, em.RecipientID, one, one)
+		if err != nil {
+			t.Errorf("Could not encrypt bad shares: %v", err)
+		}
+		return &EncryptedSharesMessage{em.Header, em.RecipientID, ciphertext}
+	}
+	run.start(ctx, count)
+	defer run.close()
+
+	// every node receives the encrypted shares for each recipient and verification points from each peer,
+	// and every node but the accuser receives its verifiable complaint
+	run.waitForPhase(t, ComplaintsPhase, count)
+	for i := range run.nodes {
+		deliveries := count * (count - 1)
+		if i != accuser {
+			deliveries++
+		}
+		run.waitForDeliveries(t, i, deliveries)
+	}
+	run.clock.Advance(run.nodes[0].timeout)
+
+	var honest []runResult
+	for _, res := range run.waitForResults(t, count) {
+		if !res.id.Equal(run.nodes[dealer].id) {
+			honest = append(honest, res)
+		}
+	}
+	checkKeyMaterialForTesting(t, curve, honest, count-1, threshold)
+}
+
+func TestRunCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// no other node takes part
+	run := startRunForTesting(ctx, t, 3, 1, 2)
+	defer run.close()
+	run.waitForPhase(t, ShareDistributionPhase, 1)
+	cancel()
+
+	results := run.waitForResults(t, 1)
+	if results[0].km != nil || results[0].err != context.Canceled {
+		t.Errorf("Got unexpected result for cancelled run: %v, %v", results[0].km, results[0].err)
+	}
+
+	if _, err := run.nodes[0].Run(context.Background(), run.transports[0], nil, nil); reflect.TypeOf(err) != reflect.TypeOf(AlreadyStartedError{}) {
+		t.Errorf("Got unexpected error running a node twice: %v", err)
+	}
+}
+
+func TestRunDropsOtherSessions(t *testing.T) {
+	nodes := generateGroupForTesting(t, 2, 2)
+	n, peer := nodes[0], nodes[1]
+	n.otherParticipants, n.participants = nil, nil
+	if err := n.Start(len(nodes)); err != nil {
+		t.Fatalf("Could not start protocol: %v", err)
+	}
+
+	r := &runner{n: n, sessionID: []byte("session"), peers: []kyber.Scalar{peer.id}, disclosed: make(map[string]bool)}
+	share1, share2 := peer.EvaluatePolynomials(n.id)
+	r.handle(&SecretSharesMessage{Header{peer.id, []byte("other session")}, n.id, share1, share2})
+	if participants := n.Participants(); len(participants) != 0 {
+		t.Errorf("Handled secret shares from another session")
+	}
+
+	r.handle(&SecretSharesMessage{Header{peer.id, []byte("session")}, n.id, share1, share2})
+	if participants := n.Participants(); len(participants) != 1 || participants[0].secretShare1 == nil {
+		t.Errorf("Didn't handle secret shares from the node's session")
+	}
+}
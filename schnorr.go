@@ -0,0 +1,77 @@
+package dkg
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+
+	"github.com/dedis/kyber"
+)
+
+// hashToScalar hashes length-prefixed parts into a scalar of the given group. SHA-512 is used so that
+// reducing the digest modulo the group order introduces negligible bias.
+func hashToScalar(group kyber.Group, parts ...[]byte) kyber.Scalar {
+	return group.Scalar().SetBytes(hashParts(parts...))
+}
+
+// hashParts hashes length-prefixed parts with SHA-512
+func hashParts(parts ...[]byte) []byte {
+	h := sha512.New()
+	for _, part := range parts {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(part)))
+		h.Write(length[:])
+		h.Write(part)
+	}
+	return h.Sum(nil)
+}
+
+// marshalAll concatenates the encodings of points and scalars for hashing
+func marshalAll(values ...kyber.Marshaling) []byte {
+	var b []byte
+	for _, v := range values {
+		encoded, _ := v.MarshalBinary()
+		b = append(b, encoded...)
+	}
+	return b
+}
+
+// schnorrSign produces a Schnorr signature over msg with the given private key. The signature is the
+// encoding of the commitment R followed by the response s. The nonce is derived deterministically from
+// the private key and message so that no randomness is needed.
+func schnorrSign(group kyber.Group, private kyber.Scalar, msg []byte) ([]byte, error) {
+	privateBytes, err := private.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	k := hashToScalar(group, []byte("dkg schnorr nonce"), privateBytes, msg)
+	R := group.Point().Mul(k, nil)
+	public := group.Point().Mul(private, nil)
+
+	c := hashToScalar(group, []byte("dkg schnorr challenge"), marshalAll(R, public), msg)
+	s := group.Scalar().Add(k, group.Scalar().Mul(c, private))
+	return marshalAll(R, s), nil
+}
+
+// schnorrVerify checks a signature produced by schnorrSign against the given public key.
+func schnorrVerify(group kyber.Group, public kyber.Point, msg, sig []byte) error {
+	if len(sig) != group.PointLen()+group.ScalarLen() {
+		return InvalidSignatureError{}
+	}
+	R := group.Point()
+	if err := R.UnmarshalBinary(sig[:group.PointLen()]); err != nil {
+		return InvalidSignatureError{}
+	}
+	s := group.Scalar()
+	if err := s.UnmarshalBinary(sig[group.PointLen():]); err != nil {
+		return InvalidSignatureError{}
+	}
+
+	c := hashToScalar(group, []byte("dkg schnorr challenge"), marshalAll(R, public), msg)
+	// s * G == R + c * P
+	lhs := group.Point().Mul(s, nil)
+	rhs := group.Point().Add(R, group.Point().Mul(c, public))
+	if !lhs.Equal(rhs) {
+		return InvalidSignatureError{}
+	}
+	return nil
+}
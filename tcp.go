@@ -0,0 +1,347 @@
+package dkg
+
+import (
+	"context"
+	"encoding"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/dedis/kyber"
+)
+
+// maxFrameSize bounds the length of a single framed message accepted from a peer
+const maxFrameSize = 1 << 20
+
+const (
+	// tcpDialTimeout bounds how long dialing a peer may take
+	tcpDialTimeout = 10 * time.Second
+	// tcpWriteTimeout bounds how long writing a frame to a peer may take
+	tcpWriteTimeout = 10 * time.Second
+)
+
+// TCPPeer is an entry of a node's static peer list
+type TCPPeer struct {
+	// The peer's node ID
+	ID kyber.Scalar
+	// The address the peer listens on, e.g. "10.0.0.2:7000"
+	Address string
+}
+
+// TCPTransport is a Transport which exchanges length-prefixed binary encoded messages with the peers of
+// a static peer list over TCP. Connections to peers are dialed on first use. Dialing and writing to a peer
+// time out, so that an unreachable or unresponsive peer can't hold up sending to the others. Messages are
+// sent unencrypted, so secret shares should only be sent over it encrypted.
+type TCPTransport struct {
+	group    kyber.Group
+	id       kyber.Scalar
+	listener net.Listener
+
+	dialTimeout  time.Duration
+	writeTimeout time.Duration
+
+	mu       sync.Mutex
+	peers    map[string]TCPPeer
+	outbound map[string]*tcpConn
+	open     map[net.Conn]struct{}
+	closed   bool
+
+	in   chan Message
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// ListenTCP starts a TCPTransport for the node with the given ID listening on address. Messages received
+// from peers are decoded with the given group.
+func ListenTCP(group kyber.Group, id kyber.Scalar, address string, peers []TCPPeer) (*TCPTransport, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TCPTransport{
+		group:        group,
+		id:           id,
+		listener:     listener,
+		dialTimeout:  tcpDialTimeout,
+		writeTimeout: tcpWriteTimeout,
+		peers:        make(map[string]TCPPeer),
+		outbound:     make(map[string]*tcpConn),
+		open:         make(map[net.Conn]struct{}),
+		in:           make(chan Message),
+		done:         make(chan struct{}),
+	}
+	for _, peer := range peers {
+		if err := t.AddPeer(peer); err != nil {
+			listener.Close()
+			return nil, err
+		}
+	}
+
+	t.wg.Add(1)
+	go t.accept()
+	return t, nil
+}
+
+// Addr returns the address the transport listens on.
+func (t *TCPTransport) Addr() net.Addr {
+	return t.listener.Addr()
+}
+
+// AddPeer adds a node to the transport's peer list.
+func (t *TCPTransport) AddPeer(peer TCPPeer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := scalarKey(peer.ID)
+	if _, ok := t.peers[key]; ok || peer.ID.Equal(t.id) {
+		return DuplicateEndpointError{peer.ID}
+	}
+	t.peers[key] = peer
+	return nil
+}
+
+// Send delivers a message to the peer with the given ID.
+func (t *TCPTransport) Send(to kyber.Scalar, m Message) error {
+	frame, err := encodeFrame(m)
+	if err != nil {
+		return err
+	}
+	return t.sendFrame(scalarKey(to), to, frame)
+}
+
+// Broadcast delivers a message to every peer concurrently, returning the first error encountered.
+func (t *TCPTransport) Broadcast(m Message) error {
+	frame, err := encodeFrame(m)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	peers := make([]TCPPeer, 0, len(t.peers))
+	for _, peer := range t.peers {
+		peers = append(peers, peer)
+	}
+	t.mu.Unlock()
+
+	errs := make(chan error, len(peers))
+	for _, peer := range peers {
+		go func(peer TCPPeer) {
+			errs <- t.sendFrame(scalarKey(peer.ID), peer.ID, frame)
+		}(peer)
+	}
+
+	var firstErr error
+	for range peers {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Receive returns the channel on which decoded messages from peers arrive.
+func (t *TCPTransport) Receive() <-chan Message {
+	return t.in
+}
+
+// Close stops listening, closes every connection and closes the receive channel.
+func (t *TCPTransport) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	close(t.done)
+	err := t.listener.Close()
+	for conn := range t.open {
+		conn.Close()
+	}
+	t.mu.Unlock()
+
+	t.wg.Wait()
+	close(t.in)
+	return err
+}
+
+// tcpConn is the outgoing connection to a peer. Writes to a peer are serialized so frames never interleave.
+type tcpConn struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// track registers an open connection so that it is closed along with the transport
+func (t *TCPTransport) track(conn net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	t.open[conn] = struct{}{}
+	return true
+}
+
+// untrack forgets about a connection and closes it
+func (t *TCPTransport) untrack(conn net.Conn) {
+	t.mu.Lock()
+	delete(t.open, conn)
+	t.mu.Unlock()
+	conn.Close()
+}
+
+// dial connects to a peer, giving up once the dial timeout passes or the transport is closed
+func (t *TCPTransport) dial(address string) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-t.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	dialer := net.Dialer{Timeout: t.dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		select {
+		case <-t.done:
+			return nil, TransportClosedError{t.id}
+		default:
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// sendFrame writes a frame to a peer, dialing it if there is no open connection yet. A connection which
+// fails or times out is dropped and redialed once.
+func (t *TCPTransport) sendFrame(key string, to kyber.Scalar, frame []byte) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return TransportClosedError{t.id}
+	}
+	peer, ok := t.peers[key]
+	if !ok {
+		t.mu.Unlock()
+		return UnknownRecipientError{to}
+	}
+	out, ok := t.outbound[key]
+	if !ok {
+		out = &tcpConn{}
+		t.outbound[key] = out
+	}
+	t.mu.Unlock()
+
+	out.mu.Lock()
+	defer out.mu.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if out.conn == nil {
+			conn, err := t.dial(peer.Address)
+			if err != nil {
+				return err
+			}
+			if !t.track(conn) {
+				conn.Close()
+				return TransportClosedError{t.id}
+			}
+			out.conn = conn
+		}
+		out.conn.SetWriteDeadline(time.Now().Add(t.writeTimeout))
+		if _, err = out.conn.Write(frame); err == nil {
+			return nil
+		}
+		t.untrack(out.conn)
+		out.conn = nil
+	}
+	return err
+}
+
+// accept hands every incoming connection to its own reader until the listener is closed
+func (t *TCPTransport) accept() {
+	defer t.wg.Done()
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		t.mu.Lock()
+		if t.closed {
+			t.mu.Unlock()
+			conn.Close()
+			return
+		}
+		t.open[conn] = struct{}{}
+		t.wg.Add(1)
+		t.mu.Unlock()
+
+		go t.read(conn)
+	}
+}
+
+// read decodes framed messages from a connection onto the receive channel. Frames which don't decode to
+// a valid message are skipped, while a broken or oversized frame closes the connection.
+func (t *TCPTransport) read(conn net.Conn) {
+	defer t.wg.Done()
+	defer t.untrack(conn)
+
+	for {
+		data, err := readFrame(conn)
+		if err != nil {
+			return
+		}
+		m, err := UnmarshalMessage(data, t.group)
+		if err != nil {
+			continue
+		}
+
+		select {
+		case t.in <- m:
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// encodeFrame encodes a message prefixed with its length as a 4 byte big-endian integer
+func encodeFrame(m Message) ([]byte, error) {
+	marshaler, ok := m.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, UnknownMessageTypeError{m.Type()}
+	}
+	data, err := marshaler.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxFrameSize {
+		return nil, InvalidMessageEncodingError{errTooLong}
+	}
+
+	frame := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[4:], data)
+	return frame, nil
+}
+
+// readFrame reads a single length-prefixed frame
+func readFrame(r io.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	if length > maxFrameSize {
+		return nil, InvalidMessageEncodingError{errTooLong}
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
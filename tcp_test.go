@@ -0,0 +1,150 @@
+package dkg
+
+import (
+	"encoding/binary"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTCPTransport(t *testing.T) {
+	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
+	node, err := NewNode(
+		curve, g2, zkParam, timeout,
+		id, secretPoly1, secretPoly2,
+	)
+	if node == nil || err != nil {
+		t.Fatalf("Could not create new node: %v", err)
+	}
+
+	count := 3
+	transports := make([]*TCPTransport, count)
+	for i := range transports {
+		transports[i], err = ListenTCP(curve, curve.Scalar().SetInt64(int64(i+1)), "127.0.0.1:0", nil)
+		if err != nil {
+			t.Fatalf("Could not listen on loopback: %v", err)
+		}
+		defer transports[i].Close()
+	}
+	for _, transport := range transports {
+		for _, peer := range transports {
+			if peer != transport {
+				transport.AddPeer(TCPPeer{peer.id, peer.Addr().String()})
+			}
+		}
+	}
+
+	if err := transports[0].AddPeer(TCPPeer{transports[1].id, "127.0.0.1:1"}); reflect.TypeOf(err) != reflect.TypeOf(DuplicateEndpointError{}) {
+		t.Errorf("Got unexpected error adding duplicate peer: %v", err)
+	}
+
+	messages := getMessagesForTesting(node)
+	if err := transports[0].Send(curve.Scalar().SetInt64(99999), messages[0]); reflect.TypeOf(err) != reflect.TypeOf(UnknownRecipientError{}) {
+		t.Errorf("Got unexpected error sending to unknown peer: %v", err)
+	}
+
+	t.Run("Send", func(t *testing.T) {
+		for _, m := range messages {
+			if err := transports[0].Send(transports[1].id, m); err != nil {
+				t.Fatalf("Could not send message %v: %v", m, err)
+			}
+		}
+		for i, received := range receiveMessages(t, transports[1], len(messages)) {
+			if reflect.TypeOf(received) != reflect.TypeOf(messages[i]) || !messagesEqual(received, messages[i]) {
+				t.Errorf("Received message doesn't match:\nexpected: %v\nactual: %v", messages[i], received)
+			}
+		}
+	})
+
+	t.Run("Broadcast", func(t *testing.T) {
+		m := messages[1]
+		if err := transports[2].Broadcast(m); err != nil {
+			t.Fatalf("Could not broadcast message: %v", err)
+		}
+		for _, transport := range transports[:2] {
+			received := receiveMessages(t, transport, 1)[0]
+			if !messagesEqual(received, m) {
+				t.Errorf("Received message doesn't match:\nexpected: %v\nactual: %v", m, received)
+			}
+		}
+	})
+
+	t.Run("Unresponsive peer", func(t *testing.T) {
+		// the peer accepts connections but never reads from them
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Could not listen on loopback: %v", err)
+		}
+		defer listener.Close()
+		go func() {
+			var conns []net.Conn
+			defer func() {
+				for _, conn := range conns {
+					conn.Close()
+				}
+			}()
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conns = append(conns, conn)
+			}
+		}()
+
+		unresponsive := curve.Scalar().SetInt64(int64(count + 1))
+		transports[0].AddPeer(TCPPeer{unresponsive, listener.Addr().String()})
+		transports[0].writeTimeout = 100 * time.Millisecond
+
+		// enough frames to fill the connection's buffers, so that writes block until they time out
+		sent := make(chan struct{})
+		go func() {
+			defer close(sent)
+			frame := make([]byte, maxFrameSize)
+			for i := 0; i < 64; i++ {
+				transports[0].sendFrame(scalarKey(unresponsive), unresponsive, frame)
+			}
+		}()
+		select {
+		case <-sent:
+		case <-time.After(20 * time.Second):
+			t.Fatalf("Writing to an unresponsive peer didn't time out")
+		}
+	})
+
+	t.Run("Invalid frames", func(t *testing.T) {
+		conn, err := net.Dial("tcp", transports[0].Addr().String())
+		if err != nil {
+			t.Fatalf("Could not dial transport: %v", err)
+		}
+		defer conn.Close()
+
+		garbage := []byte{0, 0, 0, 3, 0xff, 0xff, 0xff}
+		conn.Write(garbage)
+		valid, _ := encodeFrame(messages[0])
+		conn.Write(valid)
+
+		received := receiveMessages(t, transports[0], 1)[0]
+		if !messagesEqual(received, messages[0]) {
+			t.Errorf("Garbage frame was not skipped: %v", received)
+		}
+
+		oversized := make([]byte, 4)
+		binary.BigEndian.PutUint32(oversized, maxFrameSize+1)
+		conn.Write(oversized)
+		if _, err := conn.Read(make([]byte, 1)); err == nil {
+			t.Errorf("Connection sending oversized frame was not closed")
+		}
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		transports[2].Close()
+		if _, ok := <-transports[2].Receive(); ok {
+			t.Errorf("Receive channel still open after closing transport")
+		}
+		if err := transports[2].Send(transports[0].id, messages[0]); reflect.TypeOf(err) != reflect.TypeOf(TransportClosedError{}) {
+			t.Errorf("Got unexpected error sending on closed transport: %v", err)
+		}
+	})
+}
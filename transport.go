@@ -0,0 +1,188 @@
+package dkg
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/dedis/kyber"
+)
+
+// Transport delivers dkg messages between the nodes of a group
+type Transport interface {
+	// Send delivers a message privately to the node with the given ID
+	Send(to kyber.Scalar, m Message) error
+	// Broadcast delivers a message to every other node in the group
+	Broadcast(m Message) error
+	// Receive returns the channel on which messages for this node arrive. The channel is closed
+	// once the transport is closed.
+	Receive() <-chan Message
+	// Close stops delivering messages to and from this node
+	Close() error
+}
+
+// scalarKey returns a string identifying a scalar by its canonical encoding
+func scalarKey(s kyber.Scalar) string {
+	b, _ := s.MarshalBinary()
+	return hex.EncodeToString(b)
+}
+
+// MemoryHub connects Transports within a single process, allowing a group of nodes to run the
+// protocol without a network.
+type MemoryHub struct {
+	mu        sync.Mutex
+	endpoints map[string]*memoryTransport
+}
+
+// NewMemoryHub constructs a hub without any connected nodes.
+func NewMemoryHub() *MemoryHub {
+	return &MemoryHub{endpoints: make(map[string]*memoryTransport)}
+}
+
+// Transport connects the node with the given ID to the hub.
+func (h *MemoryHub) Transport(id kyber.Scalar) (Transport, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := scalarKey(id)
+	if _, ok := h.endpoints[key]; ok {
+		return nil, DuplicateEndpointError{id}
+	}
+
+	t := &memoryTransport{
+		hub:      h,
+		id:       id,
+		key:      key,
+		out:      make(chan Message),
+		done:     make(chan struct{}),
+		received: make(chan struct{}, 1),
+	}
+	h.endpoints[key] = t
+	go t.pump()
+	return t, nil
+}
+
+// endpoint looks up the transport connected for the node with the given ID
+func (h *MemoryHub) endpoint(id kyber.Scalar) (*memoryTransport, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t, ok := h.endpoints[scalarKey(id)]
+	return t, ok
+}
+
+// others lists every connected transport except the given one
+func (h *MemoryHub) others(key string) []*memoryTransport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	others := make([]*memoryTransport, 0, len(h.endpoints))
+	for k, t := range h.endpoints {
+		if k != key {
+			others = append(others, t)
+		}
+	}
+	return others
+}
+
+// memoryTransport is a node's connection to a MemoryHub. Incoming messages are queued without bound so
+// that senders never block on slow receivers.
+type memoryTransport struct {
+	hub *MemoryHub
+	id  kyber.Scalar
+	key string
+
+	mu       sync.Mutex
+	queue    []Message
+	closed   bool
+	out      chan Message
+	done     chan struct{}
+	received chan struct{}
+}
+
+func (t *memoryTransport) Send(to kyber.Scalar, m Message) error {
+	if t.isClosed() {
+		return TransportClosedError{t.id}
+	}
+	recipient, ok := t.hub.endpoint(to)
+	if !ok {
+		return UnknownRecipientError{to}
+	}
+	recipient.enqueue(m)
+	return nil
+}
+
+func (t *memoryTransport) Broadcast(m Message) error {
+	if t.isClosed() {
+		return TransportClosedError{t.id}
+	}
+	for _, recipient := range t.hub.others(t.key) {
+		recipient.enqueue(m)
+	}
+	return nil
+}
+
+func (t *memoryTransport) Receive() <-chan Message {
+	return t.out
+}
+
+func (t *memoryTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	close(t.done)
+
+	t.hub.mu.Lock()
+	delete(t.hub.endpoints, t.key)
+	t.hub.mu.Unlock()
+	return nil
+}
+
+func (t *memoryTransport) isClosed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closed
+}
+
+// enqueue adds a message to the transport's queue and wakes up its pump
+func (t *memoryTransport) enqueue(m Message) {
+	t.mu.Lock()
+	if !t.closed {
+		t.queue = append(t.queue, m)
+	}
+	t.mu.Unlock()
+
+	select {
+	case t.received <- struct{}{}:
+	default:
+	}
+}
+
+// pump moves queued messages onto the receive channel until the transport is closed
+func (t *memoryTransport) pump() {
+	defer close(t.out)
+	for {
+		t.mu.Lock()
+		var next Message
+		if len(t.queue) > 0 {
+			next = t.queue[0]
+			t.queue = t.queue[1:]
+		}
+		t.mu.Unlock()
+
+		if next == nil {
+			select {
+			case <-t.received:
+				continue
+			case <-t.done:
+				return
+			}
+		}
+
+		select {
+		case t.out <- next:
+		case <-t.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package dkg
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+// receiveMessages waits for count messages to arrive on a transport
+func receiveMessages(t *testing.T, transport Transport, count int) []Message {
+	var messages []Message
+	for len(messages) < count {
+		select {
+		case m, ok := <-transport.Receive():
+			if !ok {
+				t.Fatalf("Transport closed after receiving %v of %v messages", len(messages), count)
+			}
+			messages = append(messages, m)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out after receiving %v of %v messages", len(messages), count)
+		}
+	}
+	return messages
+}
+
+func TestMemoryHub(t *testing.T) {
+	curve, g2, zkParam, timeout, _, _, _ := getValidNodeParamsForTesting(t)
+	count, threshold := 4, 3
+	session := []byte("memory hub test")
+
+	hub := NewMemoryHub()
+	nodes := make([]*Node, count)
+	transports := make([]Transport, count)
+	for i := range nodes {
+		id := curve.Scalar().SetInt64(int64(i + 1))
+		n, err := GenerateNode(
+			curve, g2, zkParam, timeout,
+			id, bn256.NewSuite().RandomStream(), threshold,
+		)
+		if n == nil || err != nil {
+			t.Fatalf("Could not generate node: %v", err)
+		}
+		nodes[i] = n
+		if transports[i], err = hub.Transport(id); err != nil {
+			t.Fatalf("Could not connect node to hub: %v", err)
+		}
+	}
+
+	if _, err := hub.Transport(nodes[0].id); reflect.TypeOf(err) != reflect.TypeOf(DuplicateEndpointError{}) {
+		t.Errorf("Got unexpected error connecting duplicate node: %v", err)
+	}
+	if err := transports[0].Send(curve.Scalar().SetInt64(99999), &VerificationPointsMessage{}); reflect.TypeOf(err) != reflect.TypeOf(UnknownRecipientError{}) {
+		t.Errorf("Got unexpected error sending to unknown node: %v", err)
+	}
+
+	// every node deals its shares privately and broadcasts its verification points
+	for i, dealer := range nodes {
+		header := Header{dealer.id, session}
+		for _, recipient := range nodes {
+			if recipient == dealer {
+				continue
+			}
+			share1, share2 := dealer.EvaluatePolynomials(recipient.id)
+			err := transports[i].Send(recipient.id, &SecretSharesMessage{header, recipient.id, share1, share2})
+			if err != nil {
+				t.Fatalf("Could not send secret shares: %v", err)
+			}
+		}
+		if err := transports[i].Broadcast(&VerificationPointsMessage{header, dealer.VerificationPoints()}); err != nil {
+			t.Fatalf("Could not broadcast verification points: %v", err)
+		}
+	}
+
+	for i, n := range nodes {
+		shares := make(map[string]*SecretSharesMessage)
+		vpts := make(map[string]PointTuple)
+		senders := make(map[string]kyber.Scalar)
+		for _, m := range receiveMessages(t, transports[i], 2*(count-1)) {
+			key := scalarKey(m.Sender())
+			senders[key] = m.Sender()
+			switch m := m.(type) {
+			case *SecretSharesMessage:
+				if !m.RecipientID.Equal(n.id) {
+					t.Errorf("Node %v received shares meant for %v", n.id, m.RecipientID)
+				}
+				shares[key] = m
+			case *VerificationPointsMessage:
+				vpts[key] = m.VerificationPoints
+			}
+		}
+
+		if len(shares) != count-1 || len(vpts) != count-1 {
+			t.Fatalf("Node %v received shares from %v and points from %v nodes", n.id, len(shares), len(vpts))
+		}
+		for key, id := range senders {
+			addParticipantToNodeList(n, id, shares[key].SecretShare1, shares[key].SecretShare2, vpts[key])
+			if ok, err := n.ProcessSecretShareVerification(id); !ok || err != nil {
+				t.Errorf("Node %v could not verify shares from %v: %v", n.id, id, err)
+			}
+		}
+	}
+
+	t.Run("Close", func(t *testing.T) {
+		transports[0].Close()
+		if err := transports[0].Broadcast(&VerificationPointsMessage{}); reflect.TypeOf(err) != reflect.TypeOf(TransportClosedError{}) {
+			t.Errorf("Got unexpected error broadcasting on closed transport: %v", err)
+		}
+		if _, ok := <-transports[0].Receive(); ok {
+			t.Errorf("Receive channel still open after closing transport")
+		}
+		if err := transports[1].Send(nodes[0].id, &VerificationPointsMessage{}); reflect.TypeOf(err) != reflect.TypeOf(UnknownRecipientError{}) {
+			t.Errorf("Got unexpected error sending to disconnected node: %v", err)
+		}
+	})
+}